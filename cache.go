@@ -38,11 +38,27 @@ type tagChainCache struct {
 	transformer FieldTransformer
 	next        *tagChainCache
 	keysChain   *tagChainCache
+	exit        *tagChainCache
+	collision   *keyCollision
+	sources     []int
+	sourcePart  string
+	field       string
+	custom      bool
+}
+
+// following returns the tag after the current one in the same context, which for TagDive is the one after its TagExit
+func (t *tagChainCache) following() *tagChainCache {
+	if t.tag == TagDive {
+		return t.exit
+	}
+
+	return t.next
 }
 
 type fieldCache struct {
-	index int
-	tags  *tagChainCache
+	index        int
+	tags         *tagChainCache
+	dependencies []int
 }
 
 type structCache struct {
@@ -55,10 +71,12 @@ type cache struct {
 	transformers map[string]FieldTransformer
 	structsCache map[string]*structCache
 	mutex        *sync.RWMutex
+	customTags   map[string]bool
+	keyMergers   map[string]KeyMerger
 }
 
 func (c *cache) getStructCache(structValue *reflect.Value, structType *reflect.Type) (*structCache, error) {
-	key := fmt.Sprintf("%s/%s", (*structType).PkgPath(), (*structType).Name())
+	key := fmt.Sprintf("%s:%s/%s", c.tagName, (*structType).PkgPath(), (*structType).Name())
 
 	// safe read
 	c.mutex.RLock()
@@ -99,26 +117,34 @@ func (c *cache) buildStructCache(structValue *reflect.Value) (*structCache, erro
 		}
 
 		var tags *tagChainCache
+		var dependencies []int
 		if len(tagsRaw) > 0 {
-			paramsKey := getParamsKey(structValue.Type().String(), i)
+			// the params are stored per tag name, so the same field can have different params in each tag
+			paramsKey := getParamsKey(c.tagName+":"+structValue.Type().String(), i)
 			tagsCache, err := c.buildTagsCache(&tagsRaw, &paramsKey)
 			if err != nil {
 				return nil, err
 			}
 
-			if tagsCache != nil {
-				tagsCache.paramsKey = &paramsKey
+			if dependencies, err = resolveDependencies(strutType, &field, tagsCache); err != nil {
+				return nil, err
 			}
 
 			tags = tagsCache
 		}
 
 		fields = append(fields, &fieldCache{
-			index: i,
-			tags:  tags,
+			index:        i,
+			tags:         tags,
+			dependencies: dependencies,
 		})
 	}
 
+	fields, err := orderByDependencies(strutType, fields)
+	if err != nil {
+		return nil, err
+	}
+
 	return &structCache{
 		fieldsLength: len(fields),
 		fields:       fields,
@@ -130,46 +156,64 @@ func (c *cache) buildTagsCache(tagsRaw, paramsKey *string) (*tagChainCache, erro
 		return r == TagSeparator
 	})
 
+	i := 0
+	return c.buildTagChain(allTags, &i, *paramsKey, false)
+}
+
+// buildTagChain builds the chain of the tags starting at index i, which ends with the tags or, when it is nested in
+// TagDive or TagKeys, with TagExit. The tags of the items of a collection are chained to TagDive using next and the
+// tags following their TagExit using exit.
+func (c *cache) buildTagChain(allTags []string, i *int, paramsKey string, nested bool) (*tagChainCache, error) {
 	tags := &tagChainCache{}
 	currentTag := tags
 
-	for i := 0; i < len(allTags); i++ {
-		tag := allTags[i]
-		newTagCache, err := c.buildTagCache(tag, paramsKey)
+	for ; *i < len(allTags); *i++ {
+		tag := allTags[*i]
+		if nested && tag == TagExit {
+			break
+		}
+
+		newTagCache, err := c.buildTagCache(tag, getTagParamsKey(paramsKey, *i))
 		if err != nil {
 			return nil, err
 		}
 
-		if tag == TagKeys && i+1 < len(allTags) {
-			i++
-			keyTagCache := &tagChainCache{}
-			currentKeyTagCache := keyTagCache
-			for ; i < len(allTags); i++ {
-				keyTag := allTags[i]
-				if keyTag == TagExit {
-					break
-				}
-
-				newKeyTagCache, errBuild := c.buildTagCache(keyTag, paramsKey)
-				if errBuild != nil {
-					return nil, errBuild
-				}
-
-				currentKeyTagCache.next = newKeyTagCache
-				currentKeyTagCache = newKeyTagCache
+		currentTag.next = newTagCache
+		currentTag = newTagCache
+
+		if newTagCache.tag == TagKeys {
+			if newTagCache.collision, err = c.getKeyCollision(*newTagCache.params); err != nil {
+				return nil, err
 			}
+		}
 
-			newTagCache.keysChain = keyTagCache.next
+		if newTagCache.tag == TagKeys && *i+1 < len(allTags) {
+			*i++
+			if newTagCache.keysChain, err = c.buildTagChain(allTags, i, paramsKey, true); err != nil {
+				return nil, err
+			}
 		}
 
-		currentTag.next = newTagCache
-		currentTag = newTagCache
+		if newTagCache.tag == TagDive {
+			*i++
+			if newTagCache.next, err = c.buildTagChain(allTags, i, paramsKey, true); err != nil {
+				return nil, err
+			}
+
+			if *i >= len(allTags) {
+				break
+			}
+
+			*i++
+			newTagCache.exit, err = c.buildTagChain(allTags, i, paramsKey, nested)
+			return tags.next, err
+		}
 	}
 
 	return tags.next, nil
 }
 
-func (c *cache) buildTagCache(tag string, paramsKey *string) (*tagChainCache, error) {
+func (c *cache) buildTagCache(tag, paramsKey string) (*tagChainCache, error) {
 	params := ""
 	equalSignIndex := strings.IndexRune(tag, ParamsSign)
 
@@ -180,14 +224,15 @@ func (c *cache) buildTagCache(tag string, paramsKey *string) (*tagChainCache, er
 
 	c.mutex.RLock()
 	tr, ok := c.transformers[tag]
+	custom := c.customTags[tag]
 	c.mutex.RUnlock()
 
-	if !ok && !(navigationalTags[tag]) {
+	if !ok && !(navigationalTags[tag]) && !(crossFieldTags[tag]) {
 		return nil, newErrorf(ErrUnknownTagFmt, tag)
 	}
 
 	if tr != nil {
-		if err := tr.Cache(&params, paramsKey); err != nil {
+		if err := tr.Cache(&params, &paramsKey); err != nil {
 			return nil, err
 		}
 	}
@@ -195,6 +240,8 @@ func (c *cache) buildTagCache(tag string, paramsKey *string) (*tagChainCache, er
 	return &tagChainCache{
 		tag:         tag,
 		params:      &params,
+		paramsKey:   &paramsKey,
 		transformer: tr,
+		custom:      custom,
 	}, nil
 }
@@ -0,0 +1,260 @@
+/*
+	MIT License
+
+	Copyright (c) 2022 Antony Jekov
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in all
+	copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+	SOFTWARE.
+*/
+
+package morph
+
+import (
+	"reflect"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// caseLocales are the locales with special casing rules supported by TagLower and TagUpper
+var caseLocales = map[string]unicode.SpecialCase{
+	"":   nil,
+	"tr": unicode.TurkishCase,
+	"az": unicode.AzeriCase,
+}
+
+//region helpers
+
+// splitWords splits a string into words on every character which is not a letter, digit or mark and on case changes,
+// keeping acronyms together (e.g. "HTTPServer2Go" -> "HTTP", "Server2", "Go")
+func splitWords(s string) []string {
+	runes := []rune(s)
+	words := make([]string, 0)
+	start := -1
+
+	for i, r := range runes {
+		if !isWordRune(r) {
+			if start >= 0 {
+				words = append(words, string(runes[start:i]))
+				start = -1
+			}
+			continue
+		}
+
+		if start >= 0 && isCaseBoundary(runes, i) {
+			words = append(words, string(runes[start:i]))
+			start = i
+		}
+
+		if start < 0 {
+			start = i
+		}
+	}
+
+	if start >= 0 {
+		words = append(words, string(runes[start:]))
+	}
+
+	return words
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsMark(r)
+}
+
+func isCaseBoundary(runes []rune, i int) bool {
+	previous, current := runes[i-1], runes[i]
+	if !unicode.IsUpper(current) {
+		return false
+	}
+
+	if unicode.IsLower(previous) || unicode.IsDigit(previous) {
+		return true
+	}
+
+	return unicode.IsUpper(previous) && i+1 < len(runes) && unicode.IsLower(runes[i+1])
+}
+
+func capitalize(word string) string {
+	first, size := utf8.DecodeRuneInString(word)
+	if size == 0 {
+		return word
+	}
+
+	return string(unicode.ToTitle(first)) + strings.ToLower(word[size:])
+}
+
+func joinWords(s string, separator string, convert func(i int, word string) string) string {
+	words := splitWords(s)
+	for i, word := range words {
+		words[i] = convert(i, word)
+	}
+
+	return strings.Join(words, separator)
+}
+
+//endregion helpers
+
+//region Title
+
+type titleTransformer struct {
+	ParameterlessTransformer
+}
+
+func (t *titleTransformer) Transform(value *reflect.Value, _ *string) error {
+	return transformString(value, TagTitle, func(s string) string {
+		var result strings.Builder
+		wordStart := true
+		for _, r := range s {
+			switch {
+			case unicode.IsSpace(r):
+				wordStart = true
+			case wordStart:
+				r = unicode.ToTitle(r)
+				wordStart = false
+			default:
+				r = unicode.ToLower(r)
+			}
+
+			result.WriteRune(r)
+		}
+
+		return result.String()
+	})
+}
+
+//endregion Title
+
+//region Sentence
+
+type sentenceTransformer struct {
+	ParameterlessTransformer
+}
+
+func (t *sentenceTransformer) Transform(value *reflect.Value, _ *string) error {
+	return transformString(value, TagSentence, func(s string) string {
+		var result strings.Builder
+		sentenceStart, sentenceEnd := true, false
+		for _, r := range s {
+			// a sentence ends only at punctuation followed by whitespace, so that e.g. "3.14" or "node.js" are kept
+			if sentenceEnd && unicode.IsSpace(r) {
+				sentenceStart = true
+			}
+			sentenceEnd = r == '.' || r == '!' || r == '?'
+
+			switch {
+			case sentenceEnd:
+			case sentenceStart && unicode.IsLetter(r):
+				r = unicode.ToTitle(r)
+				sentenceStart = false
+			default:
+				r = unicode.ToLower(r)
+			}
+
+			result.WriteRune(r)
+		}
+
+		return result.String()
+	})
+}
+
+//endregion Sentence
+
+//region Camel
+
+type camelTransformer struct {
+	ParameterlessTransformer
+}
+
+func (t *camelTransformer) Transform(value *reflect.Value, _ *string) error {
+	return transformString(value, TagCamel, func(s string) string {
+		return joinWords(s, "", func(i int, word string) string {
+			if i == 0 {
+				return strings.ToLower(word)
+			}
+
+			return capitalize(word)
+		})
+	})
+}
+
+//endregion Camel
+
+//region Pascal
+
+type pascalTransformer struct {
+	ParameterlessTransformer
+}
+
+func (t *pascalTransformer) Transform(value *reflect.Value, _ *string) error {
+	return transformString(value, TagPascal, func(s string) string {
+		return joinWords(s, "", func(_ int, word string) string {
+			return capitalize(word)
+		})
+	})
+}
+
+//endregion Pascal
+
+//region Snake
+
+type snakeTransformer struct {
+	ParameterlessTransformer
+}
+
+func (t *snakeTransformer) Transform(value *reflect.Value, _ *string) error {
+	return transformString(value, TagSnake, func(s string) string {
+		return joinWords(s, "_", func(_ int, word string) string {
+			return strings.ToLower(word)
+		})
+	})
+}
+
+//endregion Snake
+
+//region Kebab
+
+type kebabTransformer struct {
+	ParameterlessTransformer
+}
+
+func (t *kebabTransformer) Transform(value *reflect.Value, _ *string) error {
+	return transformString(value, TagKebab, func(s string) string {
+		return joinWords(s, "-", func(_ int, word string) string {
+			return strings.ToLower(word)
+		})
+	})
+}
+
+//endregion Kebab
+
+//region Constant
+
+type constantTransformer struct {
+	ParameterlessTransformer
+}
+
+func (t *constantTransformer) Transform(value *reflect.Value, _ *string) error {
+	return transformString(value, TagConstant, func(s string) string {
+		return joinWords(s, "_", func(_ int, word string) string {
+			return strings.ToUpper(word)
+		})
+	})
+}
+
+//endregion Constant
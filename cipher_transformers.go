@@ -0,0 +1,179 @@
+/*
+	MIT License
+
+	Copyright (c) 2022 Antony Jekov
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in all
+	copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+	SOFTWARE.
+*/
+
+package morph
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"math"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+const ciphertextVersion = 1
+
+// cipherTransformer encrypts with AES-GCM for TagEncrypt and decrypts for TagDecrypt. The ciphertext is encoded as
+// EncodingBase64URL and holds the version of the format, the length and the ID of the key, the nonce and the sealed
+// value. The version, the key ID and the key name are authenticated together with the value.
+type cipherTransformer struct {
+	StringParameterTransformer
+	keys *keyRing
+	tag  string
+}
+
+func newCipherTransformer(mutex *sync.RWMutex, keys *keyRing, tag string) *cipherTransformer {
+	return &cipherTransformer{NewStringParamsTransformer(mutex), keys, tag}
+}
+
+func (t *cipherTransformer) Cache(params, key *string) error {
+	name := strings.TrimSpace(*params)
+	if len(name) == 0 {
+		return newErrorf(ErrMissingParametersFmt, t.tag)
+	}
+
+	_, secret, err := t.keys.key(name, t.tag)
+	if err != nil {
+		return err
+	}
+
+	if _, err = newGCM(secret); err != nil {
+		return newErrorf(ErrInvalidKeyFmt, name, t.tag)
+	}
+
+	return t.StringParameterTransformer.Cache(&name, key)
+}
+
+func (t *cipherTransformer) Transform(value *reflect.Value, key *string) error {
+	if value.Kind() != reflect.String {
+		return newErrorf(ErrUnexpectedValue, value.Type().Kind().String(), t.tag)
+	}
+
+	if value.Len() == 0 {
+		return nil
+	}
+
+	name := t.get(key)
+	if len(name) == 0 {
+		return newErrorf(ErrMissingParametersFmt, t.tag)
+	}
+
+	var result string
+	var err error
+	if t.tag == TagEncrypt {
+		// a value which is already encrypted with the key is kept, so morphing it again does not encrypt it twice
+		if _, err = t.decrypt(value.String(), name); err == nil {
+			return nil
+		}
+
+		result, err = t.encrypt(value.String(), name)
+	} else {
+		result, err = t.decrypt(value.String(), name)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	value.SetString(result)
+	return nil
+}
+
+func (t *cipherTransformer) encrypt(plaintext, name string) (string, error) {
+	id, secret, err := t.keys.key(name, t.tag)
+	if err != nil {
+		return "", err
+	}
+
+	gcm, err := newGCM(secret)
+	if err != nil || len(id) == 0 || len(id) > math.MaxUint8 {
+		return "", newErrorf(ErrInvalidKeyFmt, name, t.tag)
+	}
+
+	payload := make([]byte, 0, 2+len(id)+gcm.NonceSize()+len(plaintext)+gcm.Overhead())
+	payload = append(payload, ciphertextVersion, byte(len(id)))
+	payload = append(payload, id...)
+	additional := additionalData(payload, name)
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err = rand.Read(nonce); err != nil {
+		return "", err
+	}
+	payload = append(payload, nonce...)
+
+	payload = gcm.Seal(payload, nonce, []byte(plaintext), additional)
+	return base64.RawURLEncoding.EncodeToString(payload), nil
+}
+
+func (t *cipherTransformer) decrypt(ciphertext, name string) (string, error) {
+	payload, err := base64.RawURLEncoding.DecodeString(ciphertext)
+	if err != nil || len(payload) < 2 || payload[0] != ciphertextVersion || len(payload) < 2+int(payload[1]) {
+		return "", newErrorf(ErrInvalidSensitiveValueFmt, t.tag)
+	}
+
+	id := string(payload[2 : 2+payload[1]])
+	additional := additionalData(payload[:2+len(id)], name)
+	payload = payload[2+len(id):]
+
+	secret, err := t.keys.keyByID(name, id, t.tag)
+	if err != nil {
+		return "", err
+	}
+
+	gcm, err := newGCM(secret)
+	if err != nil {
+		return "", newErrorf(ErrInvalidKeyFmt, name, t.tag)
+	}
+
+	if len(payload) < gcm.NonceSize() {
+		return "", newErrorf(ErrInvalidSensitiveValueFmt, t.tag)
+	}
+
+	plaintext, err := gcm.Open(nil, payload[:gcm.NonceSize()], payload[gcm.NonceSize():], additional)
+	if err != nil {
+		return "", newErrorf(ErrInvalidSensitiveValueFmt, t.tag)
+	}
+
+	return string(plaintext), nil
+}
+
+// additionalData returns the data authenticated along with a value, which is the header of the ciphertext holding
+// the version and the key ID followed by the key name
+func additionalData(header []byte, name string) []byte {
+	additional := make([]byte, 0, len(header)+len(name))
+	additional = append(additional, header...)
+	return append(additional, name...)
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,330 @@
+/*
+	MIT License
+
+	Copyright (c) 2022 Antony Jekov
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in all
+	copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+	SOFTWARE.
+*/
+
+package morph
+
+import (
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+//region Unique
+
+type uniqueTransformer struct {
+	ParameterlessTransformer
+}
+
+func (t *uniqueTransformer) transformsBytes() bool {
+	return true
+}
+
+func (t *uniqueTransformer) Transform(value *reflect.Value, _ *string) error {
+	return transformSlice(value, TagUnique, func(slice reflect.Value) (reflect.Value, error) {
+		if isHashableKind(slice.Type().Elem().Kind()) {
+			seen := make(map[interface{}]bool, slice.Len())
+			return filterItems(slice, func(item reflect.Value, _ int) bool {
+				key := item.Interface()
+				if seen[key] {
+					return false
+				}
+
+				seen[key] = true
+				return true
+			}), nil
+		}
+
+		return filterItems(slice, func(item reflect.Value, kept int) bool {
+			for i := 0; i < kept; i++ {
+				if reflect.DeepEqual(slice.Index(i).Interface(), item.Interface()) {
+					return false
+				}
+			}
+
+			return true
+		}), nil
+	})
+}
+
+// isHashableKind reports whether items of a kind can be compared by their value using a map, the rest of them, such
+// as pointers and structs, are compared using reflect.DeepEqual
+func isHashableKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128:
+		return true
+	}
+
+	return false
+}
+
+//endregion Unique
+
+//region Sort
+
+type sortTransformer struct {
+	ParameterlessTransformer
+	descending bool
+	tag        string
+}
+
+func (t *sortTransformer) transformsBytes() bool {
+	return true
+}
+
+func (t *sortTransformer) Transform(value *reflect.Value, _ *string) error {
+	return transformSlice(value, t.tag, func(slice reflect.Value) (reflect.Value, error) {
+		less, ok := getItemsLess(slice.Type().Elem())
+		if !ok {
+			return slice, newErrorf(ErrUnexpectedValue, slice.Type().Elem().Kind().String(), t.tag)
+		}
+
+		sort.SliceStable(slice.Interface(), func(i, j int) bool {
+			if t.descending {
+				return less(slice.Index(j), slice.Index(i))
+			}
+
+			return less(slice.Index(i), slice.Index(j))
+		})
+
+		return slice, nil
+	})
+}
+
+// getItemsLess returns the order of strings, which are compared byte-wise, numbers, with NaN first, booleans, with
+// false first, and time.Time values
+func getItemsLess(itemType reflect.Type) (func(a, b reflect.Value) bool, bool) {
+	if isTimeType(itemType) {
+		return func(a, b reflect.Value) bool {
+			return a.Convert(timeType).Interface().(time.Time).Before(b.Convert(timeType).Interface().(time.Time))
+		}, true
+	}
+
+	switch itemType.Kind() {
+	case reflect.String:
+		return func(a, b reflect.Value) bool {
+			return a.String() < b.String()
+		}, true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return func(a, b reflect.Value) bool {
+			return a.Int() < b.Int()
+		}, true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return func(a, b reflect.Value) bool {
+			return a.Uint() < b.Uint()
+		}, true
+	case reflect.Float32, reflect.Float64:
+		return func(a, b reflect.Value) bool {
+			return a.Float() < b.Float() || (math.IsNaN(a.Float()) && !math.IsNaN(b.Float()))
+		}, true
+	case reflect.Bool:
+		return func(a, b reflect.Value) bool {
+			return !a.Bool() && b.Bool()
+		}, true
+	}
+
+	return nil, false
+}
+
+//endregion Sort
+
+//region Compact
+
+type compactTransformer struct {
+	ParameterlessTransformer
+}
+
+func (t *compactTransformer) transformsBytes() bool {
+	return true
+}
+
+func (t *compactTransformer) Transform(value *reflect.Value, _ *string) error {
+	return transformSlice(value, TagCompact, func(slice reflect.Value) (reflect.Value, error) {
+		return filterItems(slice, func(item reflect.Value, _ int) bool {
+			return !isEmptyItem(item)
+		}), nil
+	})
+}
+
+// isEmptyItem reports whether an item is a zero value or an empty slice or map
+func isEmptyItem(item reflect.Value) bool {
+	switch item.Kind() {
+	case reflect.Slice, reflect.Map:
+		return item.Len() == 0
+	}
+
+	return item.IsZero()
+}
+
+//endregion Compact
+
+//region Limit
+
+type limitTransformer struct {
+	ParameterTransformer
+}
+
+func newLimitTransformer(mutex *sync.RWMutex) *limitTransformer {
+	return &limitTransformer{newCountParamsTransformer(mutex, TagLimit)}
+}
+
+func (t *limitTransformer) transformsBytes() bool {
+	return true
+}
+
+func (t *limitTransformer) Transform(value *reflect.Value, key *string) error {
+	limit, ok := t.Get(key)
+	if !ok {
+		return newErrorf(ErrMissingParametersFmt, TagLimit)
+	}
+
+	return transformSlice(value, TagLimit, func(slice reflect.Value) (reflect.Value, error) {
+		if slice.Len() <= limit.(int) {
+			return slice, nil
+		}
+
+		return slice.Slice3(0, limit.(int), limit.(int)), nil
+	})
+}
+
+//endregion Limit
+
+//region Reverse
+
+type reverseTransformer struct {
+	ParameterlessTransformer
+}
+
+func (t *reverseTransformer) transformsBytes() bool {
+	return true
+}
+
+func (t *reverseTransformer) Transform(value *reflect.Value, _ *string) error {
+	return transformSlice(value, TagReverse, func(slice reflect.Value) (reflect.Value, error) {
+		swap := reflect.Swapper(slice.Interface())
+		for i, j := 0, slice.Len()-1; i < j; i, j = i+1, j-1 {
+			swap(i, j)
+		}
+
+		return slice, nil
+	})
+}
+
+//endregion Reverse
+
+//region NonNil
+
+type nonNilTransformer struct {
+	ParameterlessTransformer
+}
+
+func (t *nonNilTransformer) allocates() bool {
+	return true
+}
+
+func (t *nonNilTransformer) transformsBytes() bool {
+	return true
+}
+
+func (t *nonNilTransformer) Transform(value *reflect.Value, _ *string) error {
+	switch value.Kind() {
+	case reflect.Slice:
+		if value.IsNil() {
+			value.Set(reflect.MakeSlice(value.Type(), 0, 0))
+		}
+		return nil
+	case reflect.Map:
+		if value.IsNil() {
+			value.Set(reflect.MakeMap(value.Type()))
+		}
+		return nil
+	}
+
+	return newErrorf(ErrUnexpectedValue, value.Type().Kind().String(), TagNonNil)
+}
+
+//endregion NonNil
+
+// newCountParamsTransformer parses the params of a tag as a number of items, which can't be negative
+func newCountParamsTransformer(mutex *sync.RWMutex, tag string) ParameterTransformer {
+	return NewParamsTransformer(mutex, func(params string) (interface{}, error) {
+		if len(params) == 0 {
+			return nil, newErrorf(ErrMissingParametersFmt, tag)
+		}
+
+		count, err := strconv.Atoi(params)
+		if err != nil || count < 0 {
+			return nil, newErrorf(ErrInvalidParameters, params, tag)
+		}
+
+		return count, nil
+	})
+}
+
+// transformSlice applies a transformation to a copy of a non-empty slice, so that the slices sharing its items are
+// not changed, and sets the resulting slice
+func transformSlice(value *reflect.Value, tag string, transform func(slice reflect.Value) (reflect.Value, error)) error {
+	if value.Kind() != reflect.Slice {
+		return newErrorf(ErrUnexpectedValue, value.Type().Kind().String(), tag)
+	}
+
+	if value.Len() == 0 {
+		return nil
+	}
+
+	slice := reflect.MakeSlice(value.Type(), value.Len(), value.Len())
+	reflect.Copy(slice, *value)
+
+	result, err := transform(slice)
+	if err != nil {
+		return err
+	}
+
+	value.Set(result)
+	return nil
+}
+
+// filterItems moves the items to keep, which are given the number of items kept so far, to the beginning of a slice
+// preserving their order and returns the slice of them
+func filterItems(slice reflect.Value, keep func(item reflect.Value, kept int) bool) reflect.Value {
+	kept := 0
+	for i := 0; i < slice.Len(); i++ {
+		item := slice.Index(i)
+		if !keep(item, kept) {
+			continue
+		}
+
+		if kept != i {
+			slice.Index(kept).Set(item)
+		}
+		kept++
+	}
+
+	return slice.Slice3(0, kept, kept)
+}
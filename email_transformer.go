@@ -0,0 +1,171 @@
+/*
+	MIT License
+
+	Copyright (c) 2022 Antony Jekov
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in all
+	copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+	SOFTWARE.
+*/
+
+package morph
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+const (
+	maxEmailLength      = 254
+	maxEmailLocalLength = 64
+	emailSpecials       = "!#$%&'*+-/=?^_`{|}~"
+)
+
+// the providers which ignore dots in the local part of the address
+var dotlessEmailDomains = map[string]bool{
+	"gmail.com":      true,
+	"googlemail.com": true,
+}
+
+type emailOptions struct {
+	lowerLocal bool
+	stripTag   bool
+	stripDots  bool
+}
+
+type emailTransformer struct {
+	ParameterTransformer
+}
+
+func newEmailTransformer(mutex *sync.RWMutex) *emailTransformer {
+	return &emailTransformer{NewParamsTransformer(mutex, parseEmailOptions)}
+}
+
+func parseEmailOptions(params string) (interface{}, error) {
+	options := &emailOptions{}
+	for _, option := range strings.Fields(params) {
+		switch option {
+		case EmailLowerLocal:
+			options.lowerLocal = true
+		case EmailStripTag:
+			options.stripTag = true
+		case EmailStripDots:
+			options.stripDots = true
+		default:
+			return nil, newErrorf(ErrInvalidParameters, params, TagEmail)
+		}
+	}
+
+	return options, nil
+}
+
+func (t *emailTransformer) Transform(value *reflect.Value, key *string) error {
+	options, ok := t.Get(key)
+	if !ok {
+		return newErrorf(ErrMissingParametersFmt, TagEmail)
+	}
+
+	if value.Kind() != reflect.String {
+		return newErrorf(ErrUnexpectedValue, value.Type().Kind().String(), TagEmail)
+	}
+
+	email, ok := normalizeEmail(value.String(), options.(*emailOptions))
+	if !ok {
+		return newErrorf(ErrInvalidValueFmt, value.String(), TagEmail)
+	}
+
+	value.SetString(email)
+	return nil
+}
+
+// normalizeEmail validates a dot-atom address without quoted local parts, comments or domain literals. Empty values
+// are left empty.
+func normalizeEmail(email string, options *emailOptions) (string, bool) {
+	email = strings.TrimFunc(email, isBlank)
+	if len(email) == 0 {
+		return "", true
+	}
+
+	at := strings.LastIndexByte(email, '@')
+	if at < 0 {
+		return "", false
+	}
+
+	local, domain := email[:at], email[at+1:]
+	if !isEmailLocal(local) {
+		return "", false
+	}
+
+	domain, ok := toASCIIDomain(domain)
+	if !ok || !isEmailDomain(domain) {
+		return "", false
+	}
+
+	if options.stripTag {
+		if i := strings.IndexByte(local, '+'); i > 0 {
+			local = local[:i]
+		}
+	}
+
+	if options.stripDots && dotlessEmailDomains[domain] {
+		local = strings.ReplaceAll(local, ".", "")
+	}
+
+	if options.lowerLocal {
+		local = strings.ToLower(local)
+	}
+
+	email = local + "@" + domain
+	return email, len(email) <= maxEmailLength
+}
+
+func isEmailLocal(local string) bool {
+	if len(local) == 0 || len(local) > maxEmailLocalLength || !utf8.ValidString(local) ||
+		local[0] == '.' || local[len(local)-1] == '.' || strings.Contains(local, "..") {
+		return false
+	}
+
+	for _, r := range local {
+		if r >= utf8.RuneSelf {
+			continue
+		}
+
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '.' ||
+			strings.ContainsRune(emailSpecials, r)) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func isEmailDomain(domain string) bool {
+	dot := strings.LastIndexByte(domain, '.')
+	if dot < 0 {
+		return false
+	}
+
+	for _, c := range domain[dot+1:] {
+		if c < '0' || c > '9' {
+			return true
+		}
+	}
+
+	return false
+}
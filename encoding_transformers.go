@@ -0,0 +1,195 @@
+/*
+	MIT License
+
+	Copyright (c) 2022 Antony Jekov
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in all
+	copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+	SOFTWARE.
+*/
+
+package morph
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"io"
+	"mime/quotedprintable"
+	"net/url"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+var base64Encodings = map[string]*base64.Encoding{
+	Base64Std:    base64.StdEncoding,
+	Base64URL:    base64.URLEncoding,
+	Base64RawStd: base64.RawStdEncoding,
+	Base64RawURL: base64.RawURLEncoding,
+}
+
+//region Base64
+
+type base64Transformer struct {
+	ParameterTransformer
+	tag string
+}
+
+func newBase64Transformer(mutex *sync.RWMutex, tag string) *base64Transformer {
+	return &base64Transformer{NewParamsTransformer(mutex, func(params string) (interface{}, error) {
+		variant := strings.TrimSpace(params)
+		if len(variant) == 0 {
+			variant = Base64Std
+		}
+
+		encoding, ok := base64Encodings[variant]
+		if !ok {
+			return nil, newErrorf(ErrInvalidParameters, params, tag)
+		}
+
+		return encoding, nil
+	}), tag}
+}
+
+func (t *base64Transformer) transformsBytes() bool {
+	return true
+}
+
+func (t *base64Transformer) Transform(value *reflect.Value, key *string) error {
+	parsed, ok := t.Get(key)
+	if !ok {
+		return newErrorf(ErrMissingParametersFmt, t.tag)
+	}
+
+	encoding := parsed.(*base64.Encoding)
+	return transformBytes(value, t.tag, func(data []byte) ([]byte, error) {
+		if t.tag == TagB64Encode {
+			encoded := make([]byte, encoding.EncodedLen(len(data)))
+			encoding.Encode(encoded, data)
+			return encoded, nil
+		}
+
+		decoded := make([]byte, encoding.DecodedLen(len(data)))
+		n, err := encoding.Decode(decoded, data)
+		return decoded[:n], err
+	})
+}
+
+//endregion Base64
+
+//region Hex
+
+type hexEncodeTransformer struct {
+	ParameterlessTransformer
+}
+
+func (t *hexEncodeTransformer) transformsBytes() bool {
+	return true
+}
+
+func (t *hexEncodeTransformer) Transform(value *reflect.Value, _ *string) error {
+	return transformBytes(value, TagHexEncode, func(data []byte) ([]byte, error) {
+		encoded := make([]byte, hex.EncodedLen(len(data)))
+		hex.Encode(encoded, data)
+		return encoded, nil
+	})
+}
+
+type hexDecodeTransformer struct {
+	ParameterlessTransformer
+}
+
+func (t *hexDecodeTransformer) transformsBytes() bool {
+	return true
+}
+
+func (t *hexDecodeTransformer) Transform(value *reflect.Value, _ *string) error {
+	return transformBytes(value, TagHexDecode, func(data []byte) ([]byte, error) {
+		decoded := make([]byte, hex.DecodedLen(len(data)))
+		n, err := hex.Decode(decoded, data)
+		return decoded[:n], err
+	})
+}
+
+//endregion Hex
+
+//region URL
+
+type urlEncodingTransformer struct {
+	StringParameterTransformer
+	tag string
+}
+
+func newURLEncodingTransformer(mutex *sync.RWMutex, tag string) *urlEncodingTransformer {
+	return &urlEncodingTransformer{NewStringParamsTransformer(mutex), tag}
+}
+
+func (t *urlEncodingTransformer) Cache(params, key *string) error {
+	component := strings.TrimSpace(*params)
+	if len(component) == 0 {
+		component = URLEncodeQuery
+	}
+
+	if component != URLEncodeQuery && component != URLEncodePath {
+		return newErrorf(ErrInvalidParameters, *params, t.tag)
+	}
+
+	return t.StringParameterTransformer.Cache(&component, key)
+}
+
+func (t *urlEncodingTransformer) transformsBytes() bool {
+	return true
+}
+
+func (t *urlEncodingTransformer) Transform(value *reflect.Value, key *string) error {
+	path := t.get(key) == URLEncodePath
+	return transformBytes(value, t.tag, func(data []byte) ([]byte, error) {
+		switch {
+		case t.tag == TagURLEncode && path:
+			return []byte(url.PathEscape(string(data))), nil
+		case t.tag == TagURLEncode:
+			return []byte(url.QueryEscape(string(data))), nil
+		case path:
+			decoded, err := url.PathUnescape(string(data))
+			return []byte(decoded), err
+		}
+
+		decoded, err := url.QueryUnescape(string(data))
+		return []byte(decoded), err
+	})
+}
+
+//endregion URL
+
+//region QuotedPrintable
+
+type quotedPrintableDecodeTransformer struct {
+	ParameterlessTransformer
+}
+
+func (t *quotedPrintableDecodeTransformer) transformsBytes() bool {
+	return true
+}
+
+func (t *quotedPrintableDecodeTransformer) Transform(value *reflect.Value, _ *string) error {
+	return transformBytes(value, TagQPDecode, func(data []byte) ([]byte, error) {
+		return io.ReadAll(quotedprintable.NewReader(bytes.NewReader(data)))
+	})
+}
+
+//endregion QuotedPrintable
@@ -25,6 +25,7 @@
 package morph
 
 import (
+	"errors"
 	"fmt"
 )
 
@@ -33,19 +34,38 @@ const (
 	ErrNotAStruct         = "the provided value is not a struct"
 	ErrInvalidTagName     = "invalid tag name"
 	ErrInvalidTransformer = "invalid transformer"
+	ErrInvalidPolicyName  = "invalid policy name"
+	ErrInvalidKeyProvider = "invalid key provider"
+	ErrInvalidLeafType    = "invalid leaf type"
+	ErrInvalidMergerName  = "invalid merger name"
+	ErrInvalidMerger      = "invalid merger"
 )
 
 const (
-	ErrUnknownTagFmt        = "unknown tag: '%s'"
-	ErrInvalidDiveFmt       = "cannot dive into kind: %s"
-	ErrUnexpectedValue      = "unexpected value:'%s' for tag: '%s'"
-	ErrReservedTagOverride  = "cannot override reserved tag: '%s'"
-	ErrInvalidParameters    = "invalid parameters '%s' for tag: '%s'"
-	ErrMissingParametersFmt = "missing parameters for tag: %s"
+	ErrUnknownTagFmt            = "unknown tag: '%s'"
+	ErrInvalidDiveFmt           = "cannot dive into kind: %s"
+	ErrUnexpectedValue          = "unexpected value:'%s' for tag: '%s'"
+	ErrReservedTagOverride      = "cannot override reserved tag: '%s'"
+	ErrInvalidParameters        = "invalid parameters '%s' for tag: '%s'"
+	ErrMissingParametersFmt     = "missing parameters for tag: %s"
+	ErrUnknownFieldFmt          = "unknown field: '%s' for tag: '%s'"
+	ErrIncompatibleFieldFmt     = "field '%s' cannot be used as a source for field '%s'"
+	ErrCyclicDependencyFmt      = "cyclic field dependency: %s"
+	ErrCrossFieldContextFmt     = "tag '%s' cannot be used inside dive or keys"
+	ErrOverflowFmt              = "value overflows %s for tag: '%s'"
+	ErrUnknownPolicyFmt         = "unknown policy: '%s' for tag: '%s'"
+	ErrInvalidPolicyFmt         = "invalid policy element: '%s'"
+	ErrInvalidValueFmt          = "invalid value: '%s' for tag: '%s'"
+	ErrInvalidSensitiveValueFmt = "invalid value for tag: '%s'"
+	ErrUnknownKeyFmt            = "unknown key: '%s' for tag: '%s'"
+	ErrInvalidKeyFmt            = "invalid key: '%s' for tag: '%s'"
+	ErrUnknownMergerFmt         = "unknown merger: '%s' for tag: '%s'"
+	ErrKeyCollisionFmt          = "key collision: '%s' for tag: '%s'"
 )
 
 type ErrMorph struct {
 	message string
+	format  string
 }
 
 func (c ErrMorph) Error() string {
@@ -53,9 +73,20 @@ func (c ErrMorph) Error() string {
 }
 
 func newError(message string) error {
-	return ErrMorph{message}
+	return ErrMorph{message: message}
 }
 
 func newErrorf(messageFmt string, args ...string) error {
-	return ErrMorph{fmt.Sprintf(messageFmt, args)}
+	values := make([]interface{}, len(args))
+	for i, arg := range args {
+		values[i] = arg
+	}
+
+	return ErrMorph{message: fmt.Sprintf(messageFmt, values...), format: messageFmt}
+}
+
+// isErrorOf reports whether the error was created from the given message format.
+func isErrorOf(err error, messageFmt string) bool {
+	var morphErr ErrMorph
+	return errors.As(err, &morphErr) && morphErr.format == messageFmt
 }
@@ -0,0 +1,280 @@
+/*
+	MIT License
+
+	Copyright (c) 2022 Antony Jekov
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in all
+	copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+	SOFTWARE.
+*/
+
+package morph
+
+import (
+	"reflect"
+	"strings"
+)
+
+// sourceParts extracts the parts of a source string selected by the options of TagFrom and TagDefaultFrom
+var sourceParts = map[string]func(s string) string{
+	FromLocalPart: func(s string) string {
+		if i := strings.LastIndexByte(s, '@'); i >= 0 {
+			return s[:i]
+		}
+
+		return s
+	},
+	FromDomain: func(s string) string {
+		if i := strings.LastIndexByte(s, '@'); i >= 0 {
+			return s[i+1:]
+		}
+
+		return ""
+	},
+}
+
+// resolveDependencies binds the field names of the cross-field tags in the chain to their indices in the struct and
+// returns the indices of all fields the chain depends on.
+func resolveDependencies(structType reflect.Type, field *reflect.StructField, tags *tagChainCache) ([]int, error) {
+	var dependencies []int
+
+	for currentTag := tags; currentTag != nil; currentTag = currentTag.following() {
+		if err := checkCrossFieldContext(currentTag.keysChain); err != nil {
+			return nil, err
+		}
+
+		if currentTag.tag == TagDive {
+			if err := checkCrossFieldContext(currentTag.next); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		if !crossFieldTags[currentTag.tag] {
+			continue
+		}
+
+		names := strings.Fields(*currentTag.params)
+		if len(names) == 0 {
+			return nil, newErrorf(ErrMissingParametersFmt, currentTag.tag)
+		}
+
+		if currentTag.tag != TagConcat && len(names) > 1 {
+			if _, ok := sourceParts[names[1]]; !ok || len(names) > 2 {
+				return nil, newErrorf(ErrInvalidParameters, *currentTag.params, currentTag.tag)
+			}
+
+			currentTag.sourcePart = names[1]
+			names = names[:1]
+		}
+
+		sources := make([]int, 0, len(names))
+		for _, name := range names {
+			source, ok := structType.FieldByName(name)
+			if !ok || len(source.Index) != 1 || !source.IsExported() || source.Index[0] == field.Index[0] {
+				return nil, newErrorf(ErrUnknownFieldFmt, name, currentTag.tag)
+			}
+
+			if !canCopyField(currentTag, source.Type, field.Type) {
+				return nil, newErrorf(ErrIncompatibleFieldFmt, name, field.Name)
+			}
+
+			sources = append(sources, source.Index[0])
+		}
+
+		currentTag.sources = sources
+		currentTag.field = field.Name
+		dependencies = append(dependencies, sources...)
+	}
+
+	return dependencies, nil
+}
+
+func checkCrossFieldContext(tags *tagChainCache) error {
+	for currentTag := tags; currentTag != nil; currentTag = currentTag.following() {
+		if crossFieldTags[currentTag.tag] {
+			return newErrorf(ErrCrossFieldContextFmt, currentTag.tag)
+		}
+
+		if err := checkCrossFieldContext(currentTag.keysChain); err != nil {
+			return err
+		}
+
+		if currentTag.tag == TagDive {
+			if err := checkCrossFieldContext(currentTag.next); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func canCopyField(tag *tagChainCache, sourceType, targetType reflect.Type) bool {
+	sourceType = indirectType(sourceType)
+	targetType = indirectType(targetType)
+
+	if targetType.Kind() == reflect.Struct {
+		return false
+	}
+
+	if sourceType.Kind() == reflect.Interface || targetType.Kind() == reflect.Interface {
+		return true
+	}
+
+	if tag.tag == TagConcat || len(tag.sourcePart) > 0 {
+		return sourceType.Kind() == reflect.String && targetType.Kind() == reflect.String
+	}
+
+	return canConvertType(sourceType, targetType)
+}
+
+// canConvertType reports whether a source can be converted to a target without turning numbers into runes
+func canConvertType(sourceType, targetType reflect.Type) bool {
+	if targetType.Kind() == reflect.String && sourceType.Kind() != reflect.String {
+		return false
+	}
+
+	return sourceType.ConvertibleTo(targetType)
+}
+
+func indirectType(valueType reflect.Type) reflect.Type {
+	for valueType.Kind() == reflect.Ptr {
+		valueType = valueType.Elem()
+	}
+
+	return valueType
+}
+
+// orderByDependencies sorts the fields so that every field comes after the fields it reads from.
+func orderByDependencies(structType reflect.Type, fields []*fieldCache) ([]*fieldCache, error) {
+	byIndex := make(map[int]*fieldCache, len(fields))
+	hasDependencies := false
+	for _, field := range fields {
+		byIndex[field.index] = field
+		hasDependencies = hasDependencies || len(field.dependencies) > 0
+	}
+
+	if !hasDependencies {
+		return fields, nil
+	}
+
+	const (
+		visiting = 1
+		visited  = 2
+	)
+
+	states := make(map[int]int, len(fields))
+	ordered := make([]*fieldCache, 0, len(fields))
+	path := make([]string, 0)
+
+	var visit func(field *fieldCache) error
+	visit = func(field *fieldCache) error {
+		path = append(path, structType.Field(field.index).Name)
+		defer func() { path = path[:len(path)-1] }()
+
+		switch states[field.index] {
+		case visiting:
+			return newErrorf(ErrCyclicDependencyFmt, strings.Join(path, " -> "))
+		case visited:
+			return nil
+		}
+
+		states[field.index] = visiting
+		for _, dependency := range field.dependencies {
+			if dependencyField, ok := byIndex[dependency]; ok {
+				if err := visit(dependencyField); err != nil {
+					return err
+				}
+			}
+		}
+		states[field.index] = visited
+
+		ordered = append(ordered, field)
+		return nil
+	}
+
+	for _, field := range fields {
+		if err := visit(field); err != nil {
+			return nil, err
+		}
+	}
+
+	return ordered, nil
+}
+
+// copyFromSources applies a cross-field tag by reading the already morphed sibling fields of the parent struct.
+func copyFromSources(parent, target *reflect.Value, tag *tagChainCache) error {
+	if parent == nil {
+		return newErrorf(ErrCrossFieldContextFmt, tag.tag)
+	}
+
+	switch tag.tag {
+	case TagDefaultFrom:
+		if !target.IsZero() {
+			return nil
+		}
+		fallthrough
+	case TagFrom:
+		// the values of interface fields are only known now, so they are checked like the types of the other fields
+		source := getSourceValue(parent, tag.sources[0], target.Type())
+		if len(tag.sourcePart) > 0 && source.Kind() == reflect.String && target.Kind() == reflect.String {
+			target.SetString(sourceParts[tag.sourcePart](source.String()))
+			return nil
+		}
+
+		if len(tag.sourcePart) > 0 || !canConvertType(source.Type(), target.Type()) {
+			return newErrorf(ErrIncompatibleFieldFmt, parent.Type().Field(tag.sources[0]).Name, tag.field)
+		}
+
+		target.Set(source.Convert(target.Type()))
+	case TagConcat:
+		if target.Kind() != reflect.String {
+			return newErrorf(ErrUnexpectedValue, target.Kind().String(), tag.tag)
+		}
+
+		parts := make([]string, 0, len(tag.sources))
+		for _, index := range tag.sources {
+			source := getSourceValue(parent, index, target.Type())
+			if source.Kind() != reflect.String {
+				return newErrorf(ErrUnexpectedValue, source.Kind().String(), tag.tag)
+			}
+
+			if part := source.String(); len(part) > 0 {
+				parts = append(parts, part)
+			}
+		}
+
+		target.SetString(strings.Join(parts, " "))
+	}
+
+	return nil
+}
+
+func getSourceValue(parent *reflect.Value, index int, targetType reflect.Type) reflect.Value {
+	field := parent.Field(index)
+	source := getActualValue(&field)
+
+	switch source.Kind() {
+	case reflect.Ptr:
+		return reflect.Zero(indirectType(source.Type()))
+	case reflect.Interface:
+		return reflect.Zero(targetType)
+	}
+
+	return *source
+}
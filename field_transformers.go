@@ -26,10 +26,14 @@ package morph
 
 import (
 	"math"
+	"math/big"
 	"reflect"
 	"strconv"
 	"strings"
 	"sync"
+	"time"
+	"unicode"
+	"unicode/utf8"
 )
 
 //FieldTransformer is the actual transformer being called for the fields with a corresponding tag
@@ -67,7 +71,7 @@ func NewIntParamsTransformer(mutex *sync.RWMutex) IntParameterTransformer {
 func (t *IntParameterTransformer) Cache(params, key *string) error {
 	value, err := strconv.Atoi(*params)
 	if err != nil {
-		return newErrorf(ErrInvalidParameters, TagPrecision, *params)
+		return newErrorf(ErrInvalidParameters, *params, TagPrecision)
 	}
 
 	t.Mutex.Lock()
@@ -77,6 +81,98 @@ func (t *IntParameterTransformer) Cache(params, key *string) error {
 	return nil
 }
 
+//StringParameterTransformer is used to store string params for use in the transformation process
+type StringParameterTransformer struct {
+	Values map[string]*string
+	Mutex  *sync.RWMutex
+}
+
+//NewStringParamsTransformer returns a new instance
+func NewStringParamsTransformer(mutex *sync.RWMutex) StringParameterTransformer {
+	return StringParameterTransformer{
+		make(map[string]*string),
+		mutex,
+	}
+}
+
+func (t *StringParameterTransformer) Cache(params, key *string) error {
+	value := *params
+
+	t.Mutex.Lock()
+	t.Values[*key] = &value
+	t.Mutex.Unlock()
+
+	return nil
+}
+
+func (t *StringParameterTransformer) get(key *string) string {
+	t.Mutex.RLock()
+	value, ok := t.Values[*key]
+	t.Mutex.RUnlock()
+
+	if !ok || value == nil {
+		return ""
+	}
+
+	return *value
+}
+
+//ParameterTransformer is used to parse params into an arbitrary value, such as a compiled pattern, once while
+//caching and store it for use in the transformation process
+type ParameterTransformer struct {
+	Values map[string]interface{}
+	Mutex  *sync.RWMutex
+	Parse  func(params string) (interface{}, error)
+}
+
+//NewParamsTransformer returns a new instance which uses parse to convert the params of each field
+func NewParamsTransformer(mutex *sync.RWMutex, parse func(params string) (interface{}, error)) ParameterTransformer {
+	return ParameterTransformer{
+		make(map[string]interface{}),
+		mutex,
+		parse,
+	}
+}
+
+func (t *ParameterTransformer) Cache(params, key *string) error {
+	value, err := t.Parse(*params)
+	if err != nil {
+		return err
+	}
+
+	t.Mutex.Lock()
+	t.Values[*key] = value
+	t.Mutex.Unlock()
+
+	return nil
+}
+
+//Get returns the parsed value stored for the params key
+func (t *ParameterTransformer) Get(key *string) (interface{}, bool) {
+	t.Mutex.RLock()
+	value, ok := t.Values[*key]
+	t.Mutex.RUnlock()
+
+	return value, ok
+}
+
+// allocator is implemented by transformers that should allocate nil pointers even if the result is a zero value
+type allocator interface {
+	allocates() bool
+}
+
+// bytesTransformer is implemented by transformers which handle byte slices themselves instead of receiving them as
+// strings
+type bytesTransformer interface {
+	transformsBytes() bool
+}
+
+// fieldReplacer is implemented by transformers which replace the whole field, so they are called with the field
+// itself instead of the value it points to and structs are not morphed before them
+type fieldReplacer interface {
+	replacesField() bool
+}
+
 type ParameterlessTransformer struct {
 }
 
@@ -87,12 +183,25 @@ func (t *ParameterlessTransformer) Cache(_, _ *string) error {
 //region Trim
 
 type trimTransformer struct {
-	ParameterlessTransformer
+	StringParameterTransformer
 }
 
-func (t *trimTransformer) Transform(value *reflect.Value, _ *string) error {
+func (t *trimTransformer) Cache(params, key *string) error {
+	if len(*params) > 0 && *params != TrimZeroWidth {
+		return newErrorf(ErrInvalidParameters, *params, TagTrim)
+	}
+
+	return t.StringParameterTransformer.Cache(params, key)
+}
+
+func (t *trimTransformer) Transform(value *reflect.Value, key *string) error {
 	if value.Kind() != reflect.String {
-		return newErrorf(ErrUnexpectedValue, TagTrim, value.Type().Kind().String())
+		return newErrorf(ErrUnexpectedValue, value.Type().Kind().String(), TagTrim)
+	}
+
+	if t.get(key) == TrimZeroWidth {
+		value.SetString(strings.TrimFunc(value.String(), isBlank))
+		return nil
 	}
 
 	value.SetString(strings.TrimSpace(value.String()))
@@ -104,12 +213,25 @@ func (t *trimTransformer) Transform(value *reflect.Value, _ *string) error {
 //region ToLower
 
 type toLowerTransformer struct {
-	ParameterlessTransformer
+	StringParameterTransformer
+}
+
+func (t *toLowerTransformer) Cache(params, key *string) error {
+	if _, ok := caseLocales[*params]; !ok {
+		return newErrorf(ErrInvalidParameters, *params, TagLower)
+	}
+
+	return t.StringParameterTransformer.Cache(params, key)
 }
 
-func (t *toLowerTransformer) Transform(value *reflect.Value, _ *string) error {
+func (t *toLowerTransformer) Transform(value *reflect.Value, key *string) error {
 	if value.Kind() != reflect.String {
-		return newErrorf(ErrUnexpectedValue, TagLower, value.Type().Kind().String())
+		return newErrorf(ErrUnexpectedValue, value.Type().Kind().String(), TagLower)
+	}
+
+	if locale := caseLocales[t.get(key)]; locale != nil {
+		value.SetString(strings.ToLowerSpecial(locale, value.String()))
+		return nil
 	}
 
 	value.SetString(strings.ToLower(value.String()))
@@ -121,12 +243,25 @@ func (t *toLowerTransformer) Transform(value *reflect.Value, _ *string) error {
 // region ToUpper
 
 type toUpperTransformer struct {
-	ParameterlessTransformer
+	StringParameterTransformer
+}
+
+func (t *toUpperTransformer) Cache(params, key *string) error {
+	if _, ok := caseLocales[*params]; !ok {
+		return newErrorf(ErrInvalidParameters, *params, TagUpper)
+	}
+
+	return t.StringParameterTransformer.Cache(params, key)
 }
 
-func (t *toUpperTransformer) Transform(value *reflect.Value, _ *string) error {
+func (t *toUpperTransformer) Transform(value *reflect.Value, key *string) error {
 	if value.Kind() != reflect.String {
-		return newErrorf(ErrUnexpectedValue, TagUpper, value.Type().Kind().String())
+		return newErrorf(ErrUnexpectedValue, value.Type().Kind().String(), TagUpper)
+	}
+
+	if locale := caseLocales[t.get(key)]; locale != nil {
+		value.SetString(strings.ToUpperSpecial(locale, value.String()))
+		return nil
 	}
 
 	value.SetString(strings.ToUpper(value.String()))
@@ -137,32 +272,129 @@ func (t *toUpperTransformer) Transform(value *reflect.Value, _ *string) error {
 
 //region Truncate
 
+type truncateOptions struct {
+	mode     string
+	ellipsis string
+	words    bool
+}
+
 type truncateTransformer struct {
 	IntParameterTransformer
+	options map[string]*truncateOptions
+}
+
+func newTruncateTransformer(mutex *sync.RWMutex) *truncateTransformer {
+	return &truncateTransformer{
+		NewIntParamsTransformer(mutex),
+		make(map[string]*truncateOptions),
+	}
+}
+
+func (t *truncateTransformer) Cache(params, key *string) error {
+	fields := strings.Fields(*params)
+	if len(fields) == 0 {
+		return newErrorf(ErrInvalidParameters, *params, TagTruncate)
+	}
+
+	if err := t.IntParameterTransformer.Cache(&fields[0], key); err != nil || strings.HasPrefix(fields[0], "-") {
+		return newErrorf(ErrInvalidParameters, *params, TagTruncate)
+	}
+
+	options := &truncateOptions{mode: TruncateRunes}
+	for _, option := range fields[1:] {
+		switch {
+		case option == TruncateBytes || option == TruncateRunes || option == TruncateGraphemes:
+			options.mode = option
+		case option == TruncateWords:
+			options.words = true
+		case option == TruncateEllipsis:
+			options.ellipsis = "…"
+		case strings.HasPrefix(option, TruncateEllipsis+string(ParamsSign)):
+			options.ellipsis = option[len(TruncateEllipsis)+1:]
+		default:
+			return newErrorf(ErrInvalidParameters, *params, TagTruncate)
+		}
+	}
+
+	t.Mutex.Lock()
+	t.options[*key] = options
+	t.Mutex.Unlock()
+
+	return nil
 }
 
 func (t *truncateTransformer) Transform(value *reflect.Value, paramsKey *string) error {
 	if value.Kind() != reflect.String {
-		return newErrorf(ErrUnexpectedValue, TagTruncate, value.Type().Kind().String())
+		return newErrorf(ErrUnexpectedValue, value.Type().Kind().String(), TagTruncate)
 	}
 
 	t.Mutex.RLock()
 	limit, ok := t.Values[*paramsKey]
+	options := t.options[*paramsKey]
 	t.Mutex.RUnlock()
 
-	if !ok || limit == nil {
+	if !ok || limit == nil || options == nil {
 		return newErrorf(ErrMissingParametersFmt, TagTruncate)
 	}
 
-	if *limit < 0 {
-		return newErrorf(ErrInvalidParameters)
+	value.SetString(truncateText(value.String(), *limit, options))
+	return nil
+}
+
+func truncateText(text string, limit int, options *truncateOptions) string {
+	split, size := splitRunes, func(unit string) int { return 1 }
+	switch options.mode {
+	case TruncateBytes:
+		size = func(unit string) int { return len(unit) }
+	case TruncateGraphemes:
+		split = splitGraphemes
 	}
 
-	if len(value.String()) > *limit {
-		value.SetString(value.String()[:*limit])
+	units := split(text)
+	total := 0
+	for _, unit := range units {
+		total += size(unit)
 	}
 
-	return nil
+	if total <= limit {
+		return text
+	}
+
+	ellipsis := options.ellipsis
+	budget := limit
+	for _, unit := range split(ellipsis) {
+		budget -= size(unit)
+	}
+
+	if budget < 0 {
+		budget, ellipsis = limit, ""
+	}
+
+	cut, used := 0, 0
+	for ; cut < len(units) && used+size(units[cut]) <= budget; cut++ {
+		used += size(units[cut])
+	}
+
+	if options.words && cut < len(units) && !isSpaceUnit(units[cut]) {
+		for boundary := cut - 1; boundary > 0; boundary-- {
+			if isSpaceUnit(units[boundary]) {
+				cut = boundary
+				break
+			}
+		}
+	}
+
+	result := strings.Join(units[:cut], "")
+	if options.words {
+		result = strings.TrimRightFunc(result, unicode.IsSpace)
+	}
+
+	return result + ellipsis
+}
+
+func isSpaceUnit(unit string) bool {
+	r, _ := utf8.DecodeRuneInString(unit)
+	return unicode.IsSpace(r)
 }
 
 //endregion Truncate
@@ -174,13 +406,9 @@ type ceilTransformer struct {
 }
 
 func (t *ceilTransformer) Transform(value *reflect.Value, _ *string) error {
-	if value.Kind() != reflect.Float64 && value.Kind() != reflect.Float32 {
-		return newErrorf(ErrUnexpectedValue, TagCeil, value.Type().Kind().String())
-	}
-
-	value.SetFloat(math.Ceil(value.Float()))
-
-	return nil
+	return transformNumber(value, TagCeil, true, keepInteger, func(number float64) (float64, error) {
+		return math.Ceil(number), nil
+	})
 }
 
 //endregion Ceil
@@ -192,13 +420,9 @@ type floorTransformer struct {
 }
 
 func (t *floorTransformer) Transform(value *reflect.Value, _ *string) error {
-	if value.Kind() != reflect.Float64 && value.Kind() != reflect.Float32 {
-		return newErrorf(ErrUnexpectedValue, TagFloor, value.Type().Kind().String())
-	}
-
-	value.SetFloat(math.Floor(value.Float()))
-
-	return nil
+	return transformNumber(value, TagFloor, true, keepInteger, func(number float64) (float64, error) {
+		return math.Floor(number), nil
+	})
 }
 
 //endregion Floor
@@ -210,13 +434,9 @@ type roundTransformer struct {
 }
 
 func (t *roundTransformer) Transform(value *reflect.Value, _ *string) error {
-	if value.Kind() != reflect.Float64 && value.Kind() != reflect.Float32 {
-		return newErrorf(ErrUnexpectedValue, TagRound, value.Type().Kind().String())
-	}
-
-	value.SetFloat(math.Round(value.Float()))
-
-	return nil
+	return transformNumber(value, TagRound, true, keepInteger, func(number float64) (float64, error) {
+		return math.Round(number), nil
+	})
 }
 
 //endregion Round
@@ -225,29 +445,162 @@ func (t *roundTransformer) Transform(value *reflect.Value, _ *string) error {
 
 type precisionTransformer struct {
 	IntParameterTransformer
+	modes map[string]string
 }
 
-func (t *precisionTransformer) Transform(value *reflect.Value, key *string) error {
-	if value.Kind() != reflect.Float64 && value.Kind() != reflect.Float32 {
-		return newErrorf(ErrUnexpectedValue, TagPrecision, value.Type().Kind().String())
+func newPrecisionTransformer(mutex *sync.RWMutex) *precisionTransformer {
+	return &precisionTransformer{
+		NewIntParamsTransformer(mutex),
+		make(map[string]string),
+	}
+}
+
+func (t *precisionTransformer) Cache(params, key *string) error {
+	fields := strings.Fields(*params)
+	if len(fields) == 0 || len(fields) > 2 {
+		return newErrorf(ErrInvalidParameters, *params, TagPrecision)
 	}
 
+	places, err := strconv.Atoi(fields[0])
+	if err != nil || places > maxDecimalPlaces || places < -maxDecimalPlaces {
+		return newErrorf(ErrInvalidParameters, *params, TagPrecision)
+	}
+
+	mode := RoundDown
+	if len(fields) == 2 {
+		mode = fields[1]
+	}
+
+	if !roundingModes[mode] {
+		return newErrorf(ErrInvalidParameters, *params, TagPrecision)
+	}
+
+	if err := t.IntParameterTransformer.Cache(&fields[0], key); err != nil {
+		return err
+	}
+
+	t.Mutex.Lock()
+	t.modes[*key] = mode
+	t.Mutex.Unlock()
+
+	return nil
+}
+
+func (t *precisionTransformer) Transform(value *reflect.Value, key *string) error {
 	t.Mutex.RLock()
 	precision, ok := t.Values[*key]
+	mode := t.modes[*key]
 	t.Mutex.RUnlock()
 
 	if !ok {
 		return newErrorf(ErrMissingParametersFmt, TagPrecision)
 	}
 
-	precisionValue := 1.0
-	for p := *precision; p > 0; p-- {
-		precisionValue *= 10
+	return transformDecimal(value, TagPrecision, func(number *big.Rat) *big.Rat {
+		return roundDecimal(number, *precision, mode)
+	})
+}
+
+//endregion Precision
+
+//region Default
+
+type defaultTransformer struct {
+	StringParameterTransformer
+	leaves *leafRegistry
+}
+
+func (t *defaultTransformer) allocates() bool {
+	return true
+}
+
+func (t *defaultTransformer) Cache(params, key *string) error {
+	if len(*params) == 0 {
+		return newErrorf(ErrMissingParametersFmt, TagDefault)
 	}
 
-	value.SetFloat(float64(int(value.Float()*precisionValue)) / precisionValue)
+	return t.StringParameterTransformer.Cache(params, key)
+}
 
-	return nil
+func (t *defaultTransformer) Transform(value *reflect.Value, paramsKey *string) error {
+	t.Mutex.RLock()
+	params, ok := t.Values[*paramsKey]
+	t.Mutex.RUnlock()
+
+	if !ok {
+		return newErrorf(ErrMissingParametersFmt, TagDefault)
+	}
+
+	if !value.IsZero() {
+		return nil
+	}
+
+	switch value.Kind() {
+	case reflect.String:
+		value.SetString(*params)
+		return nil
+	case reflect.Bool:
+		parsed, err := strconv.ParseBool(*params)
+		if err != nil {
+			return newErrorf(ErrInvalidParameters, *params, TagDefault)
+		}
+		value.SetBool(parsed)
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if isDurationType(value.Type(), t.leaves) {
+			parsed, err := time.ParseDuration(*params)
+			if err != nil {
+				return newErrorf(ErrInvalidParameters, *params, TagDefault)
+			}
+			value.SetInt(int64(parsed))
+			return nil
+		}
+
+		parsed, err := strconv.ParseInt(*params, 10, value.Type().Bits())
+		if err != nil {
+			return newErrorf(ErrInvalidParameters, *params, TagDefault)
+		}
+		value.SetInt(parsed)
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		parsed, err := strconv.ParseUint(*params, 10, value.Type().Bits())
+		if err != nil {
+			return newErrorf(ErrInvalidParameters, *params, TagDefault)
+		}
+		value.SetUint(parsed)
+		return nil
+	case reflect.Float32, reflect.Float64:
+		parsed, err := strconv.ParseFloat(*params, value.Type().Bits())
+		if err != nil {
+			return newErrorf(ErrInvalidParameters, *params, TagDefault)
+		}
+		value.SetFloat(parsed)
+		return nil
+	}
+
+	if isTimeType(value.Type()) {
+		parsed, err := parseDefaultTime(*params)
+		if err != nil {
+			return newErrorf(ErrInvalidParameters, *params, TagDefault)
+		}
+		value.Set(reflect.ValueOf(parsed).Convert(value.Type()))
+		return nil
+	}
+
+	return newErrorf(ErrUnexpectedValue, value.Type().Kind().String(), TagDefault)
 }
 
-//endregion Precision
+func parseDefaultTime(params string) (time.Time, error) {
+	if params == TimeNow {
+		return time.Now(), nil
+	}
+
+	parsed, err := time.Parse(time.RFC3339Nano, params)
+	if err != nil {
+		return time.Parse("2006-01-02", params)
+	}
+
+	return parsed, nil
+}
+
+//endregion Default
@@ -0,0 +1,360 @@
+//go:build ignore
+
+/*
+	MIT License
+
+	Copyright (c) 2022 Antony Jekov
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in all
+	copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+	SOFTWARE.
+*/
+
+// gen_unicode_tables generates unicode_tables.go from the Unicode Character Database and the confusables.txt of the
+// Unicode security data, read from the given URLs or directories (e.g. "go run gen_unicode_tables.go -ucd ./ucd
+// -security ./security").
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"go/format"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const unicodeVersion = "14.0.0"
+
+var (
+	ucd      = flag.String("ucd", "https://www.unicode.org/Public/"+unicodeVersion+"/ucd", "URL or directory of the UCD")
+	security = flag.String("security", "https://www.unicode.org/Public/security/"+unicodeVersion,
+		"URL or directory of the security data")
+	output = flag.String("output", "unicode_tables.go", "generated file")
+)
+
+type character struct {
+	class     int
+	mapping   []rune
+	tag       string
+	lowercase rune
+}
+
+func main() {
+	flag.Parse()
+
+	characters := make(map[rune]*character)
+	forEachRow(*ucd, "UnicodeData.txt", func(fields []string) {
+		code := parseCode(fields[0])
+		class, err := strconv.Atoi(fields[3])
+		if err != nil {
+			log.Fatalf("invalid combining class of %04X: %v", code, err)
+		}
+
+		c := &character{class: class, lowercase: code}
+		if mapping := fields[5]; len(mapping) > 0 {
+			if strings.HasPrefix(mapping, "<") {
+				i := strings.IndexByte(mapping, '>')
+				c.tag, mapping = mapping[1:i], mapping[i+1:]
+			}
+			c.mapping = parseCodes(mapping)
+		}
+
+		if len(fields[13]) > 0 {
+			c.lowercase = parseCode(fields[13])
+		}
+
+		characters[code] = c
+	})
+
+	excluded := make(map[rune]bool)
+	forEachRow(*ucd, "DerivedNormalizationProps.txt", func(fields []string) {
+		if fields[1] != "Full_Composition_Exclusion" {
+			return
+		}
+
+		first, last := parseRange(fields[0])
+		for code := first; code <= last; code++ {
+			excluded[code] = true
+		}
+	})
+
+	foldings := make(map[rune][]rune)
+	forEachRow(*ucd, "CaseFolding.txt", func(fields []string) {
+		code := parseCode(fields[0])
+		switch fields[1] {
+		case "F":
+			foldings[code] = parseCodes(fields[2])
+		case "C":
+			if _, ok := foldings[code]; !ok {
+				foldings[code] = parseCodes(fields[2])
+			}
+		}
+	})
+
+	prototypes := make(map[rune][]rune)
+	forEachRow(*security, "confusables.txt", func(fields []string) {
+		prototypes[parseCode(fields[0])] = parseCodes(fields[1])
+	})
+
+	codes := make([]rune, 0, len(characters))
+	for code := range characters {
+		codes = append(codes, code)
+	}
+	sort.Slice(codes, func(i, j int) bool { return codes[i] < codes[j] })
+
+	var decompositions, classes, compositions, caseFoldings strings.Builder
+	for _, code := range codes {
+		c := characters[code]
+		if c.mapping != nil {
+			canonical := decompose(characters, code, false)
+			compatibility := decompose(characters, code, true)
+			variant := ""
+			if c.tag == "font" || c.tag == "wide" || c.tag == "narrow" {
+				variant = "v"
+			}
+
+			switch {
+			case len(c.tag) > 0:
+				canonical = nil
+			case equalRunes(canonical, compatibility):
+				compatibility = nil
+			}
+
+			fmt.Fprintf(&decompositions, "%04X;%s;%s;%s\n", code, formatCodes(canonical), formatCodes(compatibility),
+				variant)
+
+			if len(c.tag) == 0 && len(c.mapping) == 2 && !excluded[code] {
+				fmt.Fprintf(&compositions, "%04X;%04X;%04X\n", code, c.mapping[0], c.mapping[1])
+			}
+		}
+
+		// characters without a folding fold to themselves, which matters when their lowercase differs, such as the
+		// uppercase Cherokee letters
+		folding, ok := foldings[code]
+		if !ok {
+			folding = []rune{code}
+		}
+
+		if !equalRunes(folding, []rune{c.lowercase}) {
+			fmt.Fprintf(&caseFoldings, "%04X;%s\n", code, formatCodes(folding))
+		}
+	}
+
+	var confusables strings.Builder
+	for _, code := range sortedCodes(prototypes) {
+		fmt.Fprintf(&confusables, "%04X;%s\n", code, formatCodes(prototypes[code]))
+	}
+
+	for i := 0; i < len(codes); i++ {
+		class := characters[codes[i]].class
+		if class == 0 {
+			continue
+		}
+
+		last := i
+		for last+1 < len(codes) && codes[last+1] == codes[last]+1 && characters[codes[last+1]].class == class {
+			last++
+		}
+
+		fmt.Fprintf(&classes, "%04X;%04X;%d\n", codes[i], codes[last], class)
+		i = last
+	}
+
+	license, err := readLicense()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	source := license + `
+// Code generated by gen_unicode_tables.go from the Unicode Character Database and security data ` + unicodeVersion + `. DO NOT EDIT.
+
+package morph
+
+// decompositionTable lists the full canonical and compatibility decompositions of every character except the
+// Hangul syllables as "code;canonical;compatibility;variant", where an empty decomposition means the character
+// itself or the canonical one and variant marks font, wide and narrow compatibility forms.
+const decompositionTable = ` + "`\n" + decompositions.String() + "`" + `
+
+// combiningClassTable lists the ranges of characters with a non-zero canonical combining class as "first;last;class".
+const combiningClassTable = ` + "`\n" + classes.String() + "`" + `
+
+// compositionTable lists the primary composites with their canonical pairs as "code;first;second".
+const compositionTable = ` + "`\n" + compositions.String() + "`" + `
+
+// caseFoldingTable lists the full case foldings which differ from the simple lowercase mapping as "code;folding".
+const caseFoldingTable = ` + "`\n" + caseFoldings.String() + "`" + `
+
+// confusableTable lists the prototypes of the visually confusable characters from the Unicode TR39 data as
+// "code;prototype".
+const confusableTable = ` + "`\n" + confusables.String() + "`\n"
+
+	formatted, err := format.Source([]byte(source))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := os.WriteFile(*output, formatted, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// decompose returns the full canonical or compatibility decomposition of a character, which is the character itself
+// if it has none
+func decompose(characters map[rune]*character, code rune, compatibility bool) []rune {
+	c, ok := characters[code]
+	if !ok || c.mapping == nil || len(c.tag) > 0 && !compatibility {
+		return []rune{code}
+	}
+
+	var result []rune
+	for _, r := range c.mapping {
+		result = append(result, decompose(characters, r, compatibility)...)
+	}
+
+	return result
+}
+
+// readLicense returns the license comment at the top of this file
+func readLicense() (string, error) {
+	source, err := os.ReadFile("gen_unicode_tables.go")
+	if err != nil {
+		return "", err
+	}
+
+	start, end := strings.Index(string(source), "/*"), strings.Index(string(source), "*/")
+	if start < 0 || end < start {
+		return "", fmt.Errorf("missing license in gen_unicode_tables.go")
+	}
+
+	return string(source[start:end+2]) + "\n", nil
+}
+
+// forEachRow calls row with the fields of every data line of a Unicode data file, skipping comments and empty lines
+func forEachRow(base, name string, row func(fields []string)) {
+	reader, err := openData(base, name)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer reader.Close()
+
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		line := strings.TrimPrefix(scanner.Text(), "\ufeff")
+		if i := strings.IndexByte(line, '#'); i >= 0 {
+			line = line[:i]
+		}
+
+		if len(strings.TrimSpace(line)) == 0 {
+			continue
+		}
+
+		fields := strings.Split(line, ";")
+		for i, field := range fields {
+			fields[i] = strings.TrimSpace(field)
+		}
+		row(fields)
+	}
+
+	if err := scanner.Err(); err != nil {
+		log.Fatalf("reading %s: %v", name, err)
+	}
+}
+
+func openData(base, name string) (io.ReadCloser, error) {
+	if !strings.HasPrefix(base, "http://") && !strings.HasPrefix(base, "https://") {
+		return os.Open(path.Join(base, name))
+	}
+
+	response, err := http.Get(strings.TrimSuffix(base, "/") + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode != http.StatusOK {
+		response.Body.Close()
+		return nil, fmt.Errorf("fetching %s: %s", name, response.Status)
+	}
+
+	return response.Body, nil
+}
+
+func parseCode(code string) rune {
+	value, err := strconv.ParseUint(code, 16, 32)
+	if err != nil {
+		log.Fatalf("invalid code point %q: %v", code, err)
+	}
+
+	return rune(value)
+}
+
+func parseCodes(codes string) []rune {
+	var runes []rune
+	for _, code := range strings.Fields(codes) {
+		runes = append(runes, parseCode(code))
+	}
+
+	return runes
+}
+
+func parseRange(codes string) (rune, rune) {
+	if i := strings.Index(codes, ".."); i >= 0 {
+		return parseCode(codes[:i]), parseCode(codes[i+2:])
+	}
+
+	code := parseCode(codes)
+	return code, code
+}
+
+func sortedCodes(runes map[rune][]rune) []rune {
+	codes := make([]rune, 0, len(runes))
+	for code := range runes {
+		codes = append(codes, code)
+	}
+	sort.Slice(codes, func(i, j int) bool { return codes[i] < codes[j] })
+
+	return codes
+}
+
+func formatCodes(runes []rune) string {
+	codes := make([]string, len(runes))
+	for i, r := range runes {
+		codes[i] = fmt.Sprintf("%04X", r)
+	}
+
+	return strings.Join(codes, " ")
+}
+
+func equalRunes(a, b []rune) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
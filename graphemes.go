@@ -0,0 +1,155 @@
+/*
+	MIT License
+
+	Copyright (c) 2022 Antony Jekov
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in all
+	copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+	SOFTWARE.
+*/
+
+package morph
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// splitGraphemes splits a string into user-perceived characters. It follows the extended grapheme cluster rules of
+// Unicode TR29 for combining marks, joiners, emoji modifiers, regional indicator flags, Hangul syllables and CR LF
+// without relying on the full property tables.
+func splitGraphemes(s string) []string {
+	clusters := make([]string, 0, len(s))
+	start := 0
+	var previous rune = -1
+	regionalIndicators := 0
+
+	for i, r := range s {
+		if previous >= 0 && isGraphemeBoundary(previous, r, regionalIndicators) {
+			clusters = append(clusters, s[start:i])
+			start = i
+			regionalIndicators = 0
+		}
+
+		if isRegionalIndicator(r) {
+			regionalIndicators++
+		}
+
+		previous = r
+	}
+
+	if start < len(s) {
+		clusters = append(clusters, s[start:])
+	}
+
+	return clusters
+}
+
+func isGraphemeBoundary(previous, current rune, regionalIndicators int) bool {
+	switch {
+	case previous == '\r' && current == '\n':
+		return false
+	case isControl(previous) || isControl(current):
+		return true
+	case isHangulJoined(previous, current):
+		return false
+	case isGraphemeExtend(current):
+		return false
+	case previous == zeroWidthJoiner && isPictographic(current):
+		return false
+	case isRegionalIndicator(previous) && isRegionalIndicator(current):
+		return regionalIndicators%2 == 0
+	}
+
+	return true
+}
+
+const zeroWidthJoiner = '\u200d'
+
+func isControl(r rune) bool {
+	return r == '\r' || r == '\n' || unicode.Is(unicode.Cc, r) || r == '\u2028' || r == '\u2029'
+}
+
+func isGraphemeExtend(r rune) bool {
+	return r == zeroWidthJoiner ||
+		unicode.In(r, unicode.Mn, unicode.Me, unicode.Mc) ||
+		r >= 0x1F3FB && r <= 0x1F3FF || // emoji modifiers
+		r >= 0xFE00 && r <= 0xFE0F || // variation selectors
+		r >= 0xE0020 && r <= 0xE007F // tags
+}
+
+func isPictographic(r rune) bool {
+	return unicode.Is(unicode.So, r) || r >= 0x1F000 && r <= 0x1FAFF || r >= 0x2600 && r <= 0x27BF
+}
+
+func isRegionalIndicator(r rune) bool {
+	return r >= 0x1F1E6 && r <= 0x1F1FF
+}
+
+const (
+	hangulL = iota + 1
+	hangulV
+	hangulT
+	hangulLV
+	hangulLVT
+)
+
+func hangulType(r rune) int {
+	switch {
+	case r >= 0x1100 && r <= 0x115F, r >= 0xA960 && r <= 0xA97C:
+		return hangulL
+	case r >= 0x1160 && r <= 0x11A7, r >= 0xD7B0 && r <= 0xD7C6:
+		return hangulV
+	case r >= 0x11A8 && r <= 0x11FF, r >= 0xD7CB && r <= 0xD7FB:
+		return hangulT
+	case r >= 0xAC00 && r <= 0xD7A3:
+		if (r-0xAC00)%28 == 0 {
+			return hangulLV
+		}
+		return hangulLVT
+	}
+
+	return 0
+}
+
+func isHangulJoined(previous, current rune) bool {
+	switch hangulType(previous) {
+	case hangulL:
+		switch hangulType(current) {
+		case hangulL, hangulV, hangulLV, hangulLVT:
+			return true
+		}
+	case hangulLV, hangulV:
+		return hangulType(current) == hangulV || hangulType(current) == hangulT
+	case hangulLVT, hangulT:
+		return hangulType(current) == hangulT
+	}
+
+	return false
+}
+
+// splitRunes splits a string into its runes keeping invalid bytes as separate units.
+func splitRunes(s string) []string {
+	runes := make([]string, 0, utf8.RuneCountInString(s))
+	for i := 0; i < len(s); {
+		_, size := utf8.DecodeRuneInString(s[i:])
+		runes = append(runes, s[i:i+size])
+		i += size
+	}
+
+	return runes
+}
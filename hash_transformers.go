@@ -0,0 +1,193 @@
+/*
+	MIT License
+
+	Copyright (c) 2022 Antony Jekov
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in all
+	copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+	SOFTWARE.
+*/
+
+package morph
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"hash"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+const tokenLength = 16
+
+var hashAlgorithms = map[string]func() hash.Hash{
+	HashSHA224: sha256.New224,
+	HashSHA256: sha256.New,
+	HashSHA384: sha512.New384,
+	HashSHA512: sha512.New,
+}
+
+var digestEncodings = map[string]func([]byte) string{
+	EncodingHex:       hex.EncodeToString,
+	EncodingBase64:    base64.StdEncoding.EncodeToString,
+	EncodingBase64URL: base64.RawURLEncoding.EncodeToString,
+}
+
+type hashOptions struct {
+	key       string
+	algorithm func() hash.Hash
+	encode    func([]byte) string
+}
+
+// parseHashOptions reads the optional algorithm and encoding in any order, which are SHA-256 and hex by default
+func parseHashOptions(options *hashOptions, fields []string, params, tag string) error {
+	options.algorithm, options.encode = sha256.New, hex.EncodeToString
+	algorithms, encodings := 0, 0
+	for _, field := range fields {
+		if algorithm, ok := hashAlgorithms[field]; ok {
+			options.algorithm = algorithm
+			algorithms++
+		} else if encode, ok := digestEncodings[field]; ok {
+			options.encode = encode
+			encodings++
+		} else {
+			return newErrorf(ErrInvalidParameters, params, tag)
+		}
+	}
+
+	if algorithms > 1 || encodings > 1 {
+		return newErrorf(ErrInvalidParameters, params, tag)
+	}
+
+	return nil
+}
+
+//region Hash
+
+type hashTransformer struct {
+	ParameterTransformer
+}
+
+func newHashTransformer(mutex *sync.RWMutex) *hashTransformer {
+	return &hashTransformer{NewParamsTransformer(mutex, func(params string) (interface{}, error) {
+		fields := strings.Fields(params)
+		if len(fields) == 0 {
+			return nil, newErrorf(ErrMissingParametersFmt, TagHash)
+		}
+
+		options := &hashOptions{}
+		if _, ok := hashAlgorithms[fields[0]]; !ok {
+			return nil, newErrorf(ErrInvalidParameters, params, TagHash)
+		}
+
+		return options, parseHashOptions(options, fields, params, TagHash)
+	})}
+}
+
+func (t *hashTransformer) Transform(value *reflect.Value, key *string) error {
+	parsed, ok := t.Get(key)
+	if !ok {
+		return newErrorf(ErrMissingParametersFmt, TagHash)
+	}
+
+	options := parsed.(*hashOptions)
+	return transformString(value, TagHash, func(s string) string {
+		if len(s) == 0 {
+			return s
+		}
+
+		digest := options.algorithm()
+		digest.Write([]byte(s))
+		return options.encode(digest.Sum(nil))
+	})
+}
+
+//endregion Hash
+
+//region HMAC
+
+type hmacTransformer struct {
+	ParameterTransformer
+	keys *keyRing
+	tag  string
+}
+
+func newHMACTransformer(mutex *sync.RWMutex, keys *keyRing, tag string) *hmacTransformer {
+	transformer := &hmacTransformer{keys: keys, tag: tag}
+	transformer.ParameterTransformer = NewParamsTransformer(mutex, transformer.parse)
+	return transformer
+}
+
+func (t *hmacTransformer) parse(params string) (interface{}, error) {
+	fields := strings.Fields(params)
+	if len(fields) == 0 {
+		return nil, newErrorf(ErrMissingParametersFmt, t.tag)
+	}
+
+	if _, _, err := t.keys.key(fields[0], t.tag); err != nil {
+		return nil, err
+	}
+
+	options := &hashOptions{key: fields[0]}
+	if t.tag == TagTokenize {
+		if len(fields) > 1 {
+			return nil, newErrorf(ErrInvalidParameters, params, t.tag)
+		}
+
+		options.algorithm, options.encode = sha256.New, base64.RawURLEncoding.EncodeToString
+		return options, nil
+	}
+
+	return options, parseHashOptions(options, fields[1:], params, t.tag)
+}
+
+func (t *hmacTransformer) Transform(value *reflect.Value, paramsKey *string) error {
+	parsed, ok := t.Get(paramsKey)
+	if !ok {
+		return newErrorf(ErrMissingParametersFmt, t.tag)
+	}
+
+	if value.Kind() != reflect.String {
+		return newErrorf(ErrUnexpectedValue, value.Type().Kind().String(), t.tag)
+	}
+
+	if value.Len() == 0 {
+		return nil
+	}
+
+	options := parsed.(*hashOptions)
+	_, key, err := t.keys.key(options.key, t.tag)
+	if err != nil {
+		return err
+	}
+
+	mac := hmac.New(options.algorithm, key)
+	mac.Write([]byte(value.String()))
+	sum := mac.Sum(nil)
+	if t.tag == TagTokenize {
+		sum = sum[:tokenLength]
+	}
+
+	value.SetString(options.encode(sum))
+	return nil
+}
+
+//endregion HMAC
@@ -0,0 +1,265 @@
+/*
+	MIT License
+
+	Copyright (c) 2022 Antony Jekov
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in all
+	copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+	SOFTWARE.
+*/
+
+package morph
+
+import (
+	"html"
+	"strings"
+)
+
+type htmlTokenType int
+
+const (
+	htmlText htmlTokenType = iota
+	htmlStartTag
+	htmlEndTag
+	htmlComment
+)
+
+type htmlAttribute struct {
+	name  string
+	value string
+}
+
+type htmlToken struct {
+	kind       htmlTokenType
+	data       string
+	attributes []htmlAttribute
+}
+
+// the content of these elements is not markup and ends only with the matching end tag
+var htmlRawTextElements = map[string]bool{
+	"script":   true,
+	"style":    true,
+	"textarea": true,
+	"title":    true,
+	"xmp":      true,
+	"iframe":   true,
+	"noembed":  true,
+	"noframes": true,
+}
+
+// the content of these elements is dropped along with the markup as it is not meant to be displayed as text
+var htmlDroppedElements = map[string]bool{
+	"script":   true,
+	"style":    true,
+	"template": true,
+	"iframe":   true,
+	"object":   true,
+	"noembed":  true,
+	"noframes": true,
+}
+
+var htmlVoidElements = map[string]bool{
+	"area":   true,
+	"base":   true,
+	"br":     true,
+	"col":    true,
+	"embed":  true,
+	"hr":     true,
+	"img":    true,
+	"input":  true,
+	"link":   true,
+	"meta":   true,
+	"source": true,
+	"track":  true,
+	"wbr":    true,
+}
+
+// tokenizeHTML splits a document into text, tags and comments following the HTML tokenization rules closely enough
+// for sanitization. Text tokens keep their entities, tag names and attribute names are lowered and attribute values
+// are unescaped. A tag which is not closed until the end of the input is dropped the way browsers do it.
+func tokenizeHTML(s string) []htmlToken {
+	var tokens []htmlToken
+	text := 0
+	flushText := func(end int) {
+		if end > text {
+			tokens = append(tokens, htmlToken{kind: htmlText, data: s[text:end]})
+		}
+	}
+
+	for i := 0; i < len(s); {
+		if s[i] != '<' || i+1 == len(s) {
+			i++
+			continue
+		}
+
+		next := s[i+1]
+		switch {
+		case isASCIILetter(next):
+			token, end := readHTMLTag(s, i+1, htmlStartTag)
+			flushText(i)
+			if end < 0 {
+				return tokens
+			}
+
+			tokens = append(tokens, token)
+			i, text = end, end
+			if htmlRawTextElements[token.data] {
+				end = indexHTMLEndTag(s, i, token.data)
+				flushText(end)
+				i, text = end, end
+			}
+		case next == '/' && i+2 < len(s) && isASCIILetter(s[i+2]):
+			token, end := readHTMLTag(s, i+2, htmlEndTag)
+			flushText(i)
+			if end < 0 {
+				return tokens
+			}
+
+			tokens = append(tokens, token)
+			i, text = end, end
+		case next == '!' || next == '?' || next == '/':
+			flushText(i)
+			data, end := readHTMLComment(s, i)
+			tokens = append(tokens, htmlToken{kind: htmlComment, data: data})
+			i, text = end, end
+		default:
+			i++
+		}
+	}
+
+	flushText(len(s))
+	return tokens
+}
+
+// readHTMLTag reads the tag name and attributes starting at the name and returns the index after the closing '>' or
+// -1 if the tag is never closed
+func readHTMLTag(s string, i int, kind htmlTokenType) (htmlToken, int) {
+	start := i
+	for i < len(s) && !isHTMLSpace(s[i]) && s[i] != '/' && s[i] != '>' {
+		i++
+	}
+
+	token := htmlToken{kind: kind, data: strings.ToLower(s[start:i])}
+	for i < len(s) {
+		switch c := s[i]; {
+		case c == '>':
+			return token, i + 1
+		case isHTMLSpace(c) || c == '/':
+			i++
+		default:
+			var attribute htmlAttribute
+			attribute, i = readHTMLAttribute(s, i)
+			if kind == htmlStartTag && !hasHTMLAttribute(token.attributes, attribute.name) {
+				token.attributes = append(token.attributes, attribute)
+			}
+		}
+	}
+
+	return token, -1
+}
+
+func readHTMLAttribute(s string, i int) (htmlAttribute, int) {
+	start := i
+	for i++; i < len(s) && !isHTMLSpace(s[i]) && s[i] != '/' && s[i] != '>' && s[i] != '='; i++ {
+	}
+
+	attribute := htmlAttribute{name: strings.ToLower(s[start:i])}
+	for i < len(s) && isHTMLSpace(s[i]) {
+		i++
+	}
+
+	if i == len(s) || s[i] != '=' {
+		return attribute, i
+	}
+
+	for i++; i < len(s) && isHTMLSpace(s[i]); i++ {
+	}
+
+	if i < len(s) && (s[i] == '"' || s[i] == '\'') {
+		end := strings.IndexByte(s[i+1:], s[i])
+		if end < 0 {
+			return attribute, len(s)
+		}
+
+		attribute.value = html.UnescapeString(s[i+1 : i+1+end])
+		return attribute, i + end + 2
+	}
+
+	start = i
+	for i < len(s) && !isHTMLSpace(s[i]) && s[i] != '>' {
+		i++
+	}
+
+	attribute.value = html.UnescapeString(s[start:i])
+	return attribute, i
+}
+
+// readHTMLComment reads comments, doctypes, processing instructions and invalid end tags, all of which end with '>'
+func readHTMLComment(s string, i int) (string, int) {
+	if strings.HasPrefix(s[i:], "<!--") {
+		end := strings.Index(s[i+4:], "-->")
+		if end < 0 {
+			return s[i+4:], len(s)
+		}
+
+		return s[i+4 : i+4+end], i + 4 + end + 3
+	}
+
+	end := strings.IndexByte(s[i:], '>')
+	if end < 0 {
+		return s[i+2:], len(s)
+	}
+
+	return s[i+2 : i+end], i + end + 1
+}
+
+// indexHTMLEndTag returns the index of the end tag of a raw text element or the end of the input
+func indexHTMLEndTag(s string, i int, name string) int {
+	for {
+		end := strings.Index(s[i:], "</")
+		if end < 0 {
+			return len(s)
+		}
+
+		i += end
+		closing := i + 2 + len(name)
+		if closing <= len(s) && strings.EqualFold(s[i+2:closing], name) &&
+			(closing == len(s) || isHTMLSpace(s[closing]) || s[closing] == '/' || s[closing] == '>') {
+			return i
+		}
+
+		i += 2
+	}
+}
+
+func hasHTMLAttribute(attributes []htmlAttribute, name string) bool {
+	for _, attribute := range attributes {
+		if attribute.name == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isHTMLSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f'
+}
+
+func isASCIILetter(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
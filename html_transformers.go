@@ -0,0 +1,276 @@
+/*
+	MIT License
+
+	Copyright (c) 2022 Antony Jekov
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in all
+	copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+	SOFTWARE.
+*/
+
+package morph
+
+import (
+	"html"
+	"reflect"
+	"strings"
+)
+
+//HTMLPolicy describes the markup kept by TagSanitizeHTML. Everything else is removed while keeping the text inside,
+//except for the content of elements such as script, style and template, which is always removed.
+type HTMLPolicy struct {
+	//Elements maps the names of the allowed elements to the names of the attributes allowed on them
+	Elements map[string][]string
+	//Attributes lists the attributes allowed on all of the allowed elements
+	Attributes []string
+	//URLSchemes lists the schemes allowed in URL attributes such as href and src, "http", "https" and "mailto" if
+	//empty. Relative URLs are always allowed.
+	URLSchemes []string
+}
+
+type htmlPolicy struct {
+	elements map[string]map[string]bool
+	schemes  map[string]bool
+}
+
+var defaultURLSchemes = []string{"http", "https", "mailto"}
+
+var htmlURLAttributes = map[string]bool{
+	"action":     true,
+	"background": true,
+	"cite":       true,
+	"formaction": true,
+	"href":       true,
+	"longdesc":   true,
+	"poster":     true,
+	"src":        true,
+	"xlink:href": true,
+}
+
+func newHTMLPolicy(policy HTMLPolicy) (*htmlPolicy, error) {
+	compiled := &htmlPolicy{
+		make(map[string]map[string]bool),
+		make(map[string]bool),
+	}
+
+	for element, attributes := range policy.Elements {
+		element = strings.ToLower(strings.TrimSpace(element))
+		if len(element) == 0 || htmlDroppedElements[element] {
+			return nil, newErrorf(ErrInvalidPolicyFmt, element)
+		}
+
+		allowed := make(map[string]bool)
+		for _, attribute := range append(attributes, policy.Attributes...) {
+			allowed[strings.ToLower(strings.TrimSpace(attribute))] = true
+		}
+		compiled.elements[element] = allowed
+	}
+
+	schemes := policy.URLSchemes
+	if len(schemes) == 0 {
+		schemes = defaultURLSchemes
+	}
+
+	for _, scheme := range schemes {
+		compiled.schemes[strings.ToLower(strings.TrimSpace(scheme))] = true
+	}
+
+	return compiled, nil
+}
+
+func (p *htmlPolicy) allowsURL(value string) bool {
+	value = strings.Map(func(r rune) rune {
+		if r <= ' ' || r == 0x7f {
+			return -1
+		}
+
+		return r
+	}, value)
+
+	end := strings.IndexAny(value, ":/?#")
+	if end < 0 || value[end] != ':' {
+		return true
+	}
+
+	return p.schemes[strings.ToLower(value[:end])]
+}
+
+//region StripHTML
+
+type stripHTMLTransformer struct {
+	ParameterlessTransformer
+}
+
+func (t *stripHTMLTransformer) Transform(value *reflect.Value, _ *string) error {
+	return transformString(value, TagStripHTML, stripHTML)
+}
+
+func stripHTML(s string) string {
+	var builder strings.Builder
+	var dropped string
+	depth := 0
+
+	for _, token := range tokenizeHTML(s) {
+		if depth > 0 {
+			depth += droppedDepth(token, dropped)
+			continue
+		}
+
+		switch token.kind {
+		case htmlText:
+			builder.WriteString(html.UnescapeString(token.data))
+		case htmlStartTag:
+			if htmlDroppedElements[token.data] {
+				dropped, depth = token.data, 1
+			}
+		}
+	}
+
+	return builder.String()
+}
+
+//endregion StripHTML
+
+//region EscapeHTML
+
+type escapeHTMLTransformer struct {
+	ParameterlessTransformer
+}
+
+func (t *escapeHTMLTransformer) Transform(value *reflect.Value, _ *string) error {
+	return transformString(value, TagEscapeHTML, html.EscapeString)
+}
+
+//endregion EscapeHTML
+
+//region SanitizeHTML
+
+type sanitizeHTMLTransformer struct {
+	StringParameterTransformer
+	policies map[string]*htmlPolicy
+}
+
+func (t *sanitizeHTMLTransformer) Cache(params, key *string) error {
+	name := strings.TrimSpace(*params)
+	if len(name) == 0 {
+		return newErrorf(ErrMissingParametersFmt, TagSanitizeHTML)
+	}
+
+	t.Mutex.RLock()
+	_, ok := t.policies[name]
+	t.Mutex.RUnlock()
+
+	if !ok {
+		return newErrorf(ErrUnknownPolicyFmt, name, TagSanitizeHTML)
+	}
+
+	return t.StringParameterTransformer.Cache(&name, key)
+}
+
+func (t *sanitizeHTMLTransformer) Transform(value *reflect.Value, key *string) error {
+	name := t.get(key)
+
+	t.Mutex.RLock()
+	policy, ok := t.policies[name]
+	t.Mutex.RUnlock()
+
+	if !ok {
+		return newErrorf(ErrUnknownPolicyFmt, name, TagSanitizeHTML)
+	}
+
+	return transformString(value, TagSanitizeHTML, func(s string) string {
+		return sanitizeHTML(s, policy)
+	})
+}
+
+func sanitizeHTML(s string, policy *htmlPolicy) string {
+	var builder strings.Builder
+	var open []string
+	var dropped string
+	depth := 0
+
+	for _, token := range tokenizeHTML(s) {
+		if depth > 0 {
+			depth += droppedDepth(token, dropped)
+			continue
+		}
+
+		switch token.kind {
+		case htmlText:
+			builder.WriteString(html.EscapeString(html.UnescapeString(token.data)))
+		case htmlStartTag:
+			if htmlDroppedElements[token.data] {
+				dropped, depth = token.data, 1
+				continue
+			}
+
+			attributes, ok := policy.elements[token.data]
+			if !ok {
+				continue
+			}
+
+			builder.WriteString("<" + token.data)
+			for _, attribute := range token.attributes {
+				if !attributes[attribute.name] || htmlURLAttributes[attribute.name] && !policy.allowsURL(attribute.value) {
+					continue
+				}
+
+				builder.WriteString(" " + attribute.name + `="` + html.EscapeString(attribute.value) + `"`)
+			}
+			builder.WriteString(">")
+
+			if !htmlVoidElements[token.data] {
+				open = append(open, token.data)
+			}
+		case htmlEndTag:
+			for i := len(open) - 1; i >= 0; i-- {
+				if open[i] == token.data {
+					closeHTMLElements(&builder, open[i:])
+					open = open[:i]
+					break
+				}
+			}
+		}
+	}
+
+	closeHTMLElements(&builder, open)
+	return builder.String()
+}
+
+func closeHTMLElements(builder *strings.Builder, elements []string) {
+	for i := len(elements) - 1; i >= 0; i-- {
+		builder.WriteString("</" + elements[i] + ">")
+	}
+}
+
+//endregion SanitizeHTML
+
+// droppedDepth returns the change in nesting caused by a token inside an element whose content is being dropped
+func droppedDepth(token htmlToken, dropped string) int {
+	if token.data != dropped {
+		return 0
+	}
+
+	switch token.kind {
+	case htmlStartTag:
+		return 1
+	case htmlEndTag:
+		return -1
+	}
+
+	return 0
+}
@@ -0,0 +1,191 @@
+/*
+	MIT License
+
+	Copyright (c) 2022 Antony Jekov
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in all
+	copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+	SOFTWARE.
+*/
+
+package morph
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	punycodeBase        = 36
+	punycodeTMin        = 1
+	punycodeTMax        = 26
+	punycodeSkew        = 38
+	punycodeDamp        = 700
+	punycodeInitialBias = 72
+	punycodeInitialN    = 128
+	idnaPrefix          = "xn--"
+	maxDomainLength     = 253
+	maxLabelLength      = 63
+)
+
+// toASCIIDomain lowers a domain name and converts its internationalized labels to punycode. The second result is
+// false if the domain is not a valid host name made of letters, digits and hyphens.
+func toASCIIDomain(domain string) (string, bool) {
+	domain = strings.Map(func(r rune) rune {
+		switch r {
+		case '。', '．', '｡':
+			return '.'
+		}
+
+		return r
+	}, domain)
+
+	if len(domain) == 0 || len(domain) > maxDomainLength*4 {
+		return "", false
+	}
+
+	labels := strings.Split(domain, ".")
+	for i, label := range labels {
+		label = strings.ToLower(label)
+		if !isASCII(label) {
+			encoded, ok := encodePunycode(normalize(label, TagNFC))
+			if !ok {
+				return "", false
+			}
+			label = idnaPrefix + encoded
+		}
+
+		if !isHostLabel(label) {
+			return "", false
+		}
+		labels[i] = label
+	}
+
+	domain = strings.Join(labels, ".")
+	return domain, len(domain) <= maxDomainLength
+}
+
+func isHostLabel(label string) bool {
+	if len(label) == 0 || len(label) > maxLabelLength || label[0] == '-' || label[len(label)-1] == '-' {
+		return false
+	}
+
+	for i := 0; i < len(label); i++ {
+		c := label[i]
+		if !(c >= 'a' && c <= 'z' || c >= '0' && c <= '9' || c == '-') {
+			return false
+		}
+	}
+
+	return true
+}
+
+// encodePunycode encodes a label as described in RFC 3492 without the "xn--" prefix
+func encodePunycode(label string) (string, bool) {
+	if !utf8.ValidString(label) {
+		return "", false
+	}
+
+	runes := []rune(label)
+	output := make([]byte, 0, len(label)*2)
+	for _, r := range runes {
+		if r < utf8.RuneSelf {
+			output = append(output, byte(r))
+		}
+	}
+
+	basic := len(output)
+	handled := basic
+	if basic > 0 {
+		output = append(output, '-')
+	}
+
+	n, delta, bias := rune(punycodeInitialN), 0, punycodeInitialBias
+	for handled < len(runes) {
+		next := rune(utf8.MaxRune)
+		for _, r := range runes {
+			if r >= n && r < next {
+				next = r
+			}
+		}
+
+		delta += int(next-n) * (handled + 1)
+		n = next
+
+		for _, r := range runes {
+			if r < n {
+				delta++
+			}
+
+			if r != n {
+				continue
+			}
+
+			q := delta
+			for k := punycodeBase; ; k += punycodeBase {
+				t := k - bias
+				if t < punycodeTMin {
+					t = punycodeTMin
+				} else if t > punycodeTMax {
+					t = punycodeTMax
+				}
+
+				if q < t {
+					break
+				}
+
+				output = append(output, punycodeDigit(t+(q-t)%(punycodeBase-t)))
+				q = (q - t) / (punycodeBase - t)
+			}
+
+			output = append(output, punycodeDigit(q))
+			bias = adaptPunycodeBias(delta, handled+1, handled == basic)
+			delta = 0
+			handled++
+		}
+
+		delta++
+		n++
+	}
+
+	return string(output), true
+}
+
+func adaptPunycodeBias(delta, points int, first bool) int {
+	if first {
+		delta /= punycodeDamp
+	} else {
+		delta /= 2
+	}
+
+	delta += delta / points
+	k := 0
+	for delta > ((punycodeBase-punycodeTMin)*punycodeTMax)/2 {
+		delta /= punycodeBase - punycodeTMin
+		k += punycodeBase
+	}
+
+	return k + (punycodeBase-punycodeTMin+1)*delta/(delta+punycodeSkew)
+}
+
+func punycodeDigit(digit int) byte {
+	if digit < 26 {
+		return byte('a' + digit)
+	}
+
+	return byte('0' + digit - 26)
+}
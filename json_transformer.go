@@ -0,0 +1,211 @@
+/*
+	MIT License
+
+	Copyright (c) 2022 Antony Jekov
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in all
+	copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+	SOFTWARE.
+*/
+
+package morph
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type jsonTransformer struct {
+	StringParameterTransformer
+}
+
+func (t *jsonTransformer) Cache(params, key *string) error {
+	mode := strings.TrimSpace(*params)
+	if len(mode) > 0 && mode != JSONCanonical {
+		return newErrorf(ErrInvalidParameters, *params, TagJSON)
+	}
+
+	return t.StringParameterTransformer.Cache(&mode, key)
+}
+
+func (t *jsonTransformer) transformsBytes() bool {
+	return true
+}
+
+func (t *jsonTransformer) Transform(value *reflect.Value, key *string) error {
+	canonical := t.get(key) == JSONCanonical
+	return transformBytes(value, TagJSON, func(data []byte) ([]byte, error) {
+		if len(bytes.TrimSpace(data)) == 0 {
+			return data, nil
+		}
+
+		if canonical {
+			return canonicalizeJSON(data)
+		}
+
+		var buffer bytes.Buffer
+		err := json.Compact(&buffer, data)
+		return buffer.Bytes(), err
+	})
+}
+
+// canonicalizeJSON writes a JSON document without whitespace, with sorted object keys and with numbers in their
+// shortest exact decimal form
+func canonicalizeJSON(data []byte) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var document interface{}
+	if err := decoder.Decode(&document); err != nil {
+		return nil, err
+	}
+
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, newErrorf(ErrInvalidValueFmt, string(data), TagJSON)
+	}
+
+	var buffer bytes.Buffer
+	if err := writeCanonicalJSON(&buffer, document); err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
+
+func writeCanonicalJSON(buffer *bytes.Buffer, value interface{}) error {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(value))
+		for key := range value {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		buffer.WriteByte('{')
+		for i, key := range keys {
+			if i > 0 {
+				buffer.WriteByte(',')
+			}
+
+			writeJSONString(buffer, key)
+			buffer.WriteByte(':')
+			if err := writeCanonicalJSON(buffer, value[key]); err != nil {
+				return err
+			}
+		}
+		buffer.WriteByte('}')
+	case []interface{}:
+		buffer.WriteByte('[')
+		for i, item := range value {
+			if i > 0 {
+				buffer.WriteByte(',')
+			}
+
+			if err := writeCanonicalJSON(buffer, item); err != nil {
+				return err
+			}
+		}
+		buffer.WriteByte(']')
+	case json.Number:
+		number, err := canonicalizeNumber(string(value))
+		if err != nil {
+			return err
+		}
+		buffer.WriteString(number)
+	case string:
+		writeJSONString(buffer, value)
+	case bool:
+		buffer.WriteString(strconv.FormatBool(value))
+	case nil:
+		buffer.WriteString("null")
+	}
+
+	return nil
+}
+
+func writeJSONString(buffer *bytes.Buffer, s string) {
+	encoder := json.NewEncoder(buffer)
+	encoder.SetEscapeHTML(false)
+	_ = encoder.Encode(s)
+	buffer.Truncate(buffer.Len() - 1) // removes the new line added by the encoder
+}
+
+// canonicalizeNumber rewrites a valid JSON number without leading and trailing zeros, using an exponent only for very
+// large and very small values, e.g. "1.50E+2" -> "150", "-0.0" -> "0", "1e25" -> "1e+25". Exponents which do not fit
+// in 32 bits are rejected, so the position of the decimal point cannot overflow.
+func canonicalizeNumber(number string) (string, error) {
+	original := number
+	negative := strings.HasPrefix(number, "-")
+	number = strings.TrimPrefix(number, "-")
+
+	var exponent int64
+	if i := strings.IndexAny(number, "eE"); i >= 0 {
+		parsed, err := strconv.ParseInt(strings.TrimPrefix(number[i+1:], "+"), 10, 32)
+		if err != nil {
+			return "", newErrorf(ErrUnexpectedValue, original, TagJSON)
+		}
+
+		exponent = parsed
+		number = number[:i]
+	}
+
+	digits := number
+	if i := strings.IndexByte(number, '.'); i >= 0 {
+		digits = number[:i] + number[i+1:]
+		exponent -= int64(len(number) - i - 1)
+	}
+
+	// the value is digits * 10^exponent
+	trimmed := strings.TrimRight(digits, "0")
+	exponent += int64(len(digits) - len(trimmed))
+	digits = strings.TrimLeft(trimmed, "0")
+	if len(digits) == 0 {
+		return "0", nil
+	}
+
+	sign := ""
+	if negative {
+		sign = "-"
+	}
+
+	// the position of the decimal point relative to the start of the digits
+	point := int64(len(digits)) + exponent
+	switch {
+	case point > 21 || point < -5:
+		mantissa := digits[:1]
+		if len(digits) > 1 {
+			mantissa += "." + digits[1:]
+		}
+
+		exponentSign := "+"
+		if point-1 < 0 {
+			exponentSign = ""
+		}
+		return sign + mantissa + "e" + exponentSign + strconv.FormatInt(point-1, 10), nil
+	case exponent >= 0:
+		return sign + digits + strings.Repeat("0", int(exponent)), nil
+	case point > 0:
+		return sign + digits[:point] + "." + digits[point:], nil
+	}
+
+	return sign + "0." + strings.Repeat("0", int(-point)) + digits, nil
+}
@@ -0,0 +1,127 @@
+/*
+	MIT License
+
+	Copyright (c) 2022 Antony Jekov
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in all
+	copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+	SOFTWARE.
+*/
+
+package morph
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+//KeyMerger merges the values of map keys which are morphed into the same key, when TagKeys is used with KeysMerge.
+//It is called in the order of the original keys with the morphed key, the value merged so far and the next value
+//and returns the merged value, which has to be assignable to the values of the map.
+//
+//	Example:
+//		func(key, merged, value interface{}) (interface{}, error) {
+//			return merged.(int) + value.(int), nil
+//		}
+type KeyMerger func(key, merged, value interface{}) (interface{}, error)
+
+// keyCollision is the policy of TagKeys for the values of keys which are morphed into the same key
+type keyCollision struct {
+	policy string
+	merger KeyMerger
+}
+
+// getKeyCollision parses the params of TagKeys, which default to KeysKeepLast
+func (c *cache) getKeyCollision(params string) (*keyCollision, error) {
+	policy, name := params, ""
+	if i := strings.IndexRune(params, ParamsSign); i > 0 {
+		policy, name = params[:i], params[i+1:]
+	}
+
+	switch policy {
+	case "":
+		return &keyCollision{policy: KeysKeepLast}, nil
+	case KeysError, KeysKeepFirst, KeysKeepLast:
+		if len(name) == 0 {
+			return &keyCollision{policy: policy}, nil
+		}
+	case KeysMerge:
+		if len(name) == 0 {
+			return nil, newErrorf(ErrMissingParametersFmt, TagKeys)
+		}
+
+		c.mutex.RLock()
+		merger, ok := c.keyMergers[name]
+		c.mutex.RUnlock()
+
+		if !ok {
+			return nil, newErrorf(ErrUnknownMergerFmt, name, TagKeys)
+		}
+
+		return &keyCollision{policy, merger}, nil
+	}
+
+	return nil, newErrorf(ErrInvalidParameters, params, TagKeys)
+}
+
+// resolve returns the value of a morphed key which is already set to merged
+func (k *keyCollision) resolve(key, merged, value reflect.Value) (reflect.Value, error) {
+	switch k.policy {
+	case KeysError:
+		return value, newErrorf(ErrKeyCollisionFmt, fmt.Sprint(key.Interface()), TagKeys)
+	case KeysKeepFirst:
+		return merged, nil
+	case KeysMerge:
+		result, err := k.merger(key.Interface(), merged.Interface(), value.Interface())
+		if err != nil {
+			return value, err
+		}
+
+		resultValue := reflect.ValueOf(result)
+		if !resultValue.IsValid() {
+			return reflect.Zero(value.Type()), nil
+		}
+
+		if !resultValue.Type().AssignableTo(value.Type()) {
+			return value, newErrorf(ErrUnexpectedValue, resultValue.Kind().String(), TagKeys)
+		}
+
+		return resultValue, nil
+	}
+
+	return value, nil
+}
+
+// sortedMapKeys returns the keys of a map in a deterministic order, which is the one of TagSort for the keys it can
+// sort, or the one of their Go syntax representation otherwise
+func sortedMapKeys(mapValue *reflect.Value) []reflect.Value {
+	keys := mapValue.MapKeys()
+	less, ok := getItemsLess(mapValue.Type().Key())
+	if !ok {
+		less = func(a, b reflect.Value) bool {
+			return fmt.Sprintf("%#v", a.Interface()) < fmt.Sprintf("%#v", b.Interface())
+		}
+	}
+
+	sort.SliceStable(keys, func(i, j int) bool {
+		return less(keys[i], keys[j])
+	})
+
+	return keys
+}
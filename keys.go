@@ -0,0 +1,97 @@
+/*
+	MIT License
+
+	Copyright (c) 2022 Antony Jekov
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in all
+	copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+	SOFTWARE.
+*/
+
+package morph
+
+import (
+	"sync"
+)
+
+//KeyProvider supplies the secret keys used by tags such as TagHMAC, so that only their names appear in the tags.
+// Rotating a key means returning a new ID and key from Key while still returning the retired ones from KeyByID, so
+// that the values produced with them can still be read.
+type KeyProvider interface {
+	//Key returns the ID and the current version of the key with the given name or false if there is no such key
+	Key(name string) (id string, key []byte, ok bool)
+	//KeyByID returns the version of the key with the given name and ID or false if there is no such version
+	KeyByID(name, id string) ([]byte, bool)
+}
+
+//StaticKeys is a KeyProvider holding the keys in memory by name, where the ID of each key is its name
+type StaticKeys map[string][]byte
+
+func (k StaticKeys) Key(name string) (string, []byte, bool) {
+	key, ok := k[name]
+	return name, key, ok
+}
+
+func (k StaticKeys) KeyByID(name, id string) ([]byte, bool) {
+	if name != id {
+		return nil, false
+	}
+
+	key, ok := k[name]
+	return key, ok
+}
+
+// keyRing holds the key provider registered on a Morph instance and shared by the transformers using keys
+type keyRing struct {
+	provider KeyProvider
+	mutex    *sync.RWMutex
+}
+
+func (r *keyRing) getProvider() KeyProvider {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	return r.provider
+}
+
+func (r *keyRing) key(name, tag string) (string, []byte, error) {
+	provider := r.getProvider()
+	if provider == nil {
+		return "", nil, newErrorf(ErrUnknownKeyFmt, name, tag)
+	}
+
+	id, key, ok := provider.Key(name)
+	if !ok || len(key) == 0 {
+		return "", nil, newErrorf(ErrUnknownKeyFmt, name, tag)
+	}
+
+	return id, key, nil
+}
+
+func (r *keyRing) keyByID(name, id, tag string) ([]byte, error) {
+	provider := r.getProvider()
+	if provider == nil {
+		return nil, newErrorf(ErrUnknownKeyFmt, name, tag)
+	}
+
+	key, ok := provider.KeyByID(name, id)
+	if !ok || len(key) == 0 {
+		return nil, newErrorf(ErrUnknownKeyFmt, name+"/"+id, tag)
+	}
+
+	return key, nil
+}
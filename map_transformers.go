@@ -0,0 +1,243 @@
+/*
+	MIT License
+
+	Copyright (c) 2022 Antony Jekov
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in all
+	copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+	SOFTWARE.
+*/
+
+package morph
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+//region AllowKeys
+
+type allowKeysTransformer struct {
+	ParameterTransformer
+}
+
+func (t *allowKeysTransformer) Transform(value *reflect.Value, key *string) error {
+	keys, ok := t.Get(key)
+	if !ok {
+		return newErrorf(ErrMissingParametersFmt, TagAllowKeys)
+	}
+
+	return transformKeySet(value, TagAllowKeys, func(key string) bool {
+		return keys.(map[string]bool)[key]
+	})
+}
+
+//endregion AllowKeys
+
+//region DenyKeys
+
+type denyKeysTransformer struct {
+	ParameterTransformer
+}
+
+func (t *denyKeysTransformer) Transform(value *reflect.Value, key *string) error {
+	keys, ok := t.Get(key)
+	if !ok {
+		return newErrorf(ErrMissingParametersFmt, TagDenyKeys)
+	}
+
+	return transformKeySet(value, TagDenyKeys, func(key string) bool {
+		return !keys.(map[string]bool)[key]
+	})
+}
+
+//endregion DenyKeys
+
+//region DropEmpty
+
+type dropEmptyTransformer struct {
+	ParameterlessTransformer
+}
+
+func (t *dropEmptyTransformer) Transform(value *reflect.Value, _ *string) error {
+	return transformMap(value, TagDropEmpty, func(key, item reflect.Value) (reflect.Value, bool) {
+		return key, !isEmptyItem(item)
+	})
+}
+
+//endregion DropEmpty
+
+//region KeyPrefix
+
+type keyPrefixTransformer struct {
+	ParameterTransformer
+}
+
+func (t *keyPrefixTransformer) Transform(value *reflect.Value, key *string) error {
+	prefix, ok := t.Get(key)
+	if !ok {
+		return newErrorf(ErrMissingParametersFmt, TagKeyPrefix)
+	}
+
+	return transformStringKeys(value, TagKeyPrefix, func(key string) string {
+		return prefix.(string) + key
+	})
+}
+
+//endregion KeyPrefix
+
+//region StripKeyPrefix
+
+type stripKeyPrefixTransformer struct {
+	ParameterTransformer
+}
+
+func (t *stripKeyPrefixTransformer) Transform(value *reflect.Value, key *string) error {
+	prefix, ok := t.Get(key)
+	if !ok {
+		return newErrorf(ErrMissingParametersFmt, TagStripKeyPrefix)
+	}
+
+	return transformStringKeys(value, TagStripKeyPrefix, func(key string) string {
+		return strings.TrimPrefix(key, prefix.(string))
+	})
+}
+
+//endregion StripKeyPrefix
+
+//region MaxKeys
+
+type maxKeysTransformer struct {
+	ParameterTransformer
+}
+
+func (t *maxKeysTransformer) Transform(value *reflect.Value, key *string) error {
+	limit, ok := t.Get(key)
+	if !ok {
+		return newErrorf(ErrMissingParametersFmt, TagMaxKeys)
+	}
+
+	if value.Kind() != reflect.Map {
+		return newErrorf(ErrUnexpectedValue, value.Type().Kind().String(), TagMaxKeys)
+	}
+
+	if value.Len() <= limit.(int) {
+		return nil
+	}
+
+	result := reflect.MakeMapWithSize(value.Type(), limit.(int))
+	for _, mapKey := range sortedMapKeys(value)[:limit.(int)] {
+		result.SetMapIndex(mapKey, value.MapIndex(mapKey))
+	}
+
+	value.Set(result)
+	return nil
+}
+
+//endregion MaxKeys
+
+// newKeysParamsTransformer parses the params of a tag as a set of keys separated by spaces
+func newKeysParamsTransformer(mutex *sync.RWMutex, tag string) ParameterTransformer {
+	return NewParamsTransformer(mutex, func(params string) (interface{}, error) {
+		names := strings.Fields(params)
+		if len(names) == 0 {
+			return nil, newErrorf(ErrMissingParametersFmt, tag)
+		}
+
+		keys := make(map[string]bool, len(names))
+		for _, name := range names {
+			keys[name] = true
+		}
+
+		return keys, nil
+	})
+}
+
+// newPrefixParamsTransformer keeps the params of a tag as a prefix, which can't be empty
+func newPrefixParamsTransformer(mutex *sync.RWMutex, tag string) ParameterTransformer {
+	return NewParamsTransformer(mutex, func(params string) (interface{}, error) {
+		if len(params) == 0 {
+			return nil, newErrorf(ErrMissingParametersFmt, tag)
+		}
+
+		return params, nil
+	})
+}
+
+// transformKeySet keeps the entries of a map with string or integer keys, which keep returns true for when given the
+// keys formatted as decimal text
+func transformKeySet(value *reflect.Value, tag string, keep func(key string) bool) error {
+	if value.Kind() != reflect.Map {
+		return newErrorf(ErrUnexpectedValue, value.Type().Kind().String(), tag)
+	}
+
+	var format func(key reflect.Value) string
+	switch value.Type().Key().Kind() {
+	case reflect.String:
+		format = reflect.Value.String
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		format = func(key reflect.Value) string {
+			return strconv.FormatInt(key.Int(), 10)
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		format = func(key reflect.Value) string {
+			return strconv.FormatUint(key.Uint(), 10)
+		}
+	default:
+		return newErrorf(ErrUnexpectedValue, value.Type().Key().Kind().String(), tag)
+	}
+
+	return transformMap(value, tag, func(key, _ reflect.Value) (reflect.Value, bool) {
+		return key, keep(format(key))
+	})
+}
+
+// transformStringKeys renames the keys of a map with string keys. The keys renamed into the same one keep the value of
+// the last of them in sorted order, as with the default policy of TagKeys.
+func transformStringKeys(value *reflect.Value, tag string, rename func(key string) string) error {
+	if value.Kind() == reflect.Map && value.Type().Key().Kind() != reflect.String {
+		return newErrorf(ErrUnexpectedValue, value.Type().Key().Kind().String(), tag)
+	}
+
+	return transformMap(value, tag, func(key, _ reflect.Value) (reflect.Value, bool) {
+		return reflect.ValueOf(rename(key.String())).Convert(key.Type()), true
+	})
+}
+
+// transformMap sets a copy of a non-empty map, so that the maps sharing its entries are not changed, with the keys
+// returned by apply for its keys in sorted order and the values of the ones it keeps
+func transformMap(value *reflect.Value, tag string, apply func(key, item reflect.Value) (reflect.Value, bool)) error {
+	if value.Kind() != reflect.Map {
+		return newErrorf(ErrUnexpectedValue, value.Type().Kind().String(), tag)
+	}
+
+	if value.Len() == 0 {
+		return nil
+	}
+
+	result := reflect.MakeMapWithSize(value.Type(), value.Len())
+	for _, key := range sortedMapKeys(value) {
+		if newKey, ok := apply(key, value.MapIndex(key)); ok {
+			result.SetMapIndex(newKey, value.MapIndex(key))
+		}
+	}
+
+	value.Set(result)
+	return nil
+}
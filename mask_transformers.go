@@ -0,0 +1,249 @@
+/*
+	MIT License
+
+	Copyright (c) 2022 Antony Jekov
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in all
+	copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+	SOFTWARE.
+*/
+
+package morph
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+const (
+	maskRune        = '*'
+	emailMask       = "***"
+	defaultRedacted = "[REDACTED]"
+	minCardLength   = 12
+	maxCardLength   = 19
+	cardVisible     = 4
+)
+
+//region Mask
+
+type maskOptions struct {
+	first int
+	last  int
+	mask  string
+}
+
+type maskTransformer struct {
+	ParameterTransformer
+}
+
+func newMaskTransformer(mutex *sync.RWMutex) *maskTransformer {
+	return &maskTransformer{NewParamsTransformer(mutex, parseMaskOptions)}
+}
+
+func parseMaskOptions(params string) (interface{}, error) {
+	fields := strings.Fields(params)
+	if len(fields) > 3 {
+		return nil, newErrorf(ErrInvalidParameters, params, TagMask)
+	}
+
+	options := &maskOptions{mask: string(maskRune)}
+	for i, field := range fields {
+		if i == 2 {
+			if utf8.RuneCountInString(field) != 1 {
+				return nil, newErrorf(ErrInvalidParameters, params, TagMask)
+			}
+			options.mask = field
+			continue
+		}
+
+		count, err := strconv.Atoi(field)
+		if err != nil || count < 0 {
+			return nil, newErrorf(ErrInvalidParameters, params, TagMask)
+		}
+
+		if i == 0 {
+			options.first = count
+		} else {
+			options.last = count
+		}
+	}
+
+	return options, nil
+}
+
+func (t *maskTransformer) Transform(value *reflect.Value, key *string) error {
+	options, ok := t.Get(key)
+	if !ok {
+		return newErrorf(ErrMissingParametersFmt, TagMask)
+	}
+
+	return transformString(value, TagMask, func(s string) string {
+		return maskText(s, options.(*maskOptions))
+	})
+}
+
+// maskText replaces all characters but the first and last ones with the mask. Everything is masked if nothing would
+// be hidden otherwise.
+func maskText(s string, options *maskOptions) string {
+	graphemes := splitGraphemes(s)
+	if options.first+options.last >= len(graphemes) {
+		return strings.Repeat(options.mask, len(graphemes))
+	}
+
+	hidden := len(graphemes) - options.first - options.last
+	return strings.Join(graphemes[:options.first], "") + strings.Repeat(options.mask, hidden) +
+		strings.Join(graphemes[len(graphemes)-options.last:], "")
+}
+
+//endregion Mask
+
+//region MaskEmail
+
+type maskEmailTransformer struct {
+	ParameterlessTransformer
+}
+
+func (t *maskEmailTransformer) Transform(value *reflect.Value, _ *string) error {
+	return transformString(value, TagMaskEmail, maskEmail)
+}
+
+// maskEmail keeps the first character of the local part and the domain, hiding the length of the local part
+func maskEmail(email string) string {
+	at := strings.LastIndexByte(email, '@')
+	if at <= 0 {
+		return maskText(email, &maskOptions{mask: string(maskRune)})
+	}
+
+	return splitGraphemes(email[:at])[0] + emailMask + email[at:]
+}
+
+//endregion MaskEmail
+
+//region MaskCard
+
+type maskCardTransformer struct {
+	ParameterlessTransformer
+}
+
+func (t *maskCardTransformer) Transform(value *reflect.Value, _ *string) error {
+	if value.Kind() != reflect.String {
+		return newErrorf(ErrUnexpectedValue, value.Type().Kind().String(), TagMaskCard)
+	}
+
+	if len(value.String()) == 0 {
+		return nil
+	}
+
+	masked, ok := maskCard(value.String())
+	if !ok {
+		return newErrorf(ErrInvalidSensitiveValueFmt, TagMaskCard)
+	}
+
+	value.SetString(masked)
+	return nil
+}
+
+// maskCard masks all digits of a Luhn-valid card number but the last four, keeping the spaces and hyphens between them
+func maskCard(card string) (string, bool) {
+	card = strings.TrimFunc(card, isBlank)
+
+	digits := make([]byte, 0, len(card))
+	for i := 0; i < len(card); i++ {
+		switch c := card[i]; {
+		case c >= '0' && c <= '9':
+			digits = append(digits, c)
+		case c != ' ' && c != '-':
+			return "", false
+		}
+	}
+
+	if len(digits) < minCardLength || len(digits) > maxCardLength || !isLuhnValid(digits) {
+		return "", false
+	}
+
+	masked := []byte(card)
+	hidden := len(digits) - cardVisible
+	for i := 0; i < len(masked) && hidden > 0; i++ {
+		if masked[i] >= '0' && masked[i] <= '9' {
+			masked[i] = maskRune
+			hidden--
+		}
+	}
+
+	return string(masked), true
+}
+
+func isLuhnValid(digits []byte) bool {
+	sum := 0
+	for i := range digits {
+		digit := int(digits[len(digits)-1-i] - '0')
+		if i%2 == 1 {
+			digit *= 2
+			if digit > 9 {
+				digit -= 9
+			}
+		}
+		sum += digit
+	}
+
+	return sum%10 == 0
+}
+
+//endregion MaskCard
+
+//region Redact
+
+type redactTransformer struct {
+	StringParameterTransformer
+}
+
+func (t *redactTransformer) Transform(value *reflect.Value, key *string) error {
+	text := t.get(key)
+	if len(text) == 0 {
+		text = defaultRedacted
+	}
+
+	return transformString(value, TagRedact, func(s string) string {
+		if len(s) == 0 {
+			return s
+		}
+
+		return text
+	})
+}
+
+//endregion Redact
+
+//region Zero
+
+type zeroTransformer struct {
+	ParameterlessTransformer
+}
+
+func (t *zeroTransformer) replacesField() bool {
+	return true
+}
+
+func (t *zeroTransformer) Transform(value *reflect.Value, _ *string) error {
+	value.Set(reflect.Zero(value.Type()))
+	return nil
+}
+
+//endregion Zero
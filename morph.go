@@ -32,22 +32,445 @@ import (
 
 // transformational tags
 const (
-	//TagTrim trims a string value (e.g "trim" - " value " -> "value")
+	//TagTrim trims whitespaces around a string value, or zero-width characters too with the TrimZeroWidth option (e.g
+	// "trim" - " value " -> "value")
 	TagTrim = "trim"
-	//TagLower transforms a string to lower characters (e.g "lower" - "VALUE" -> "value")
+	//TagLTrim trims whitespaces or the characters of an optional cutset from the start of a string value (e.g
+	// "ltrim=0" - "007" -> "7")
+	TagLTrim = "ltrim"
+	//TagRTrim trims whitespaces or the characters of an optional cutset from the end of a string value (e.g
+	// "rtrim=!" - "value!!" -> "value")
+	TagRTrim = "rtrim"
+	//TagTrimPrefix removes a prefix from a string value (e.g "trimprefix=+359" - "+359888" -> "888")
+	TagTrimPrefix = "trimprefix"
+	//TagTrimSuffix removes a suffix from a string value (e.g "trimsuffix=.com" - "example.com" -> "example")
+	TagTrimSuffix = "trimsuffix"
+	//TagSquish trims a string value and collapses the whitespaces inside it (e.g "squish" - " some \t value " -> "some
+	// value")
+	TagSquish = "squish"
+	//TagNoSpace removes all whitespaces from a string value (e.g "nospace" - "0888 123 456" -> "0888123456")
+	TagNoSpace = "nospace"
+	//TagNoZeroWidth removes zero-width characters such as U+200B and U+FEFF from a string value
+	TagNoZeroWidth = "nozerowidth"
+	//TagNBSP replaces non-breaking spaces with regular spaces in a string value
+	TagNBSP = "nbsp"
+	//TagEOL normalizes line endings to "lf" (default) or "crlf" (e.g "eol" - "a\r\nb\rc" -> "a\nb\nc")
+	TagEOL = "eol"
+	//TagLower transforms a string to lower characters using the rules of an optional locale (e.g "lower" - "VALUE" ->
+	// "value", "lower=tr" - "KIZ" -> "kız")
 	TagLower = "lower"
-	//TagUpper transforms a string to upper characters (e.g "upper" - "value" -> "VALUE")
+	//TagUpper transforms a string to upper characters using the rules of an optional locale (e.g "upper" - "value" ->
+	// "VALUE", "upper=tr" - "istanbul" -> "İSTANBUL")
 	TagUpper = "upper"
-	//TagTruncate truncates a string to a specified length (e.g "truncate=3" - "value" -> "val")
+	//TagTitle capitalizes the first letter of every word and lowers the rest (e.g "title" - "hello WORLD" -> "Hello
+	// World")
+	TagTitle = "title"
+	//TagSentence capitalizes the first letter of every sentence and lowers the rest (e.g "sentence" - "HELLO. BYE" ->
+	// "Hello. Bye")
+	TagSentence = "sentence"
+	//TagCamel converts a string to camelCase (e.g "camel" - "HTTP server id" -> "httpServerId")
+	TagCamel = "camel"
+	//TagPascal converts a string to PascalCase (e.g "pascal" - "http_server" -> "HttpServer")
+	TagPascal = "pascal"
+	//TagSnake converts a string to snake_case (e.g "snake" - "HTTPServer" -> "http_server")
+	TagSnake = "snake"
+	//TagKebab converts a string to kebab-case (e.g "kebab" - "HTTPServer" -> "http-server")
+	TagKebab = "kebab"
+	//TagConstant converts a string to CONSTANT_CASE (e.g "constant" - "httpServer" -> "HTTP_SERVER")
+	TagConstant = "constant"
+	//TagNFC normalizes a string to the Unicode canonical composition form (e.g "nfc" - "e\u0301" -> "é")
+	TagNFC = "nfc"
+	//TagNFD normalizes a string to the Unicode canonical decomposition form (e.g "nfd" - "é" -> "e\u0301")
+	TagNFD = "nfd"
+	//TagNFKC normalizes a string to the Unicode compatibility composition form (e.g "nfkc" - "ﬁ" -> "fi")
+	TagNFKC = "nfkc"
+	//TagNFKD normalizes a string to the Unicode compatibility decomposition form (e.g "nfkd" - "ｅ\u0301" -> "e\u0301")
+	TagNFKD = "nfkd"
+	//TagCaseFold applies full Unicode case folding for caseless comparison (e.g "casefold" - "Straße" -> "strasse")
+	TagCaseFold = "casefold"
+	//TagASCII transliterates other scripts, strips diacritics and removes the remaining non ASCII characters (e.g
+	// "ascii" - "Ćevapčići в София" -> "Cevapcici v Sofiya")
+	TagASCII = "ascii"
+	//TagConfusables replaces a string with its skeleton built from the Unicode TR39 confusables data, so visually
+	// confusable strings become equal (e.g "confusables" - "pаypаl" with Cyrillic "а" -> "paypal")
+	TagConfusables = "confusables"
+	//TagSlug converts a string to a URL slug with optional SlugSeparator, SlugMaxLength, SlugUnicode and SlugStopWords
+	// (e.g "slug" - "Здравей, Свят!" -> "zdravey-svyat", "slug=max=12 stop=a|the" - "The Tale of a Town" ->
+	// "tale-of-town")
+	TagSlug = "slug"
+	//TagReplace replaces the matches of a regular expression with the text after the first unescaped space, which
+	// may refer to submatches with $1 or ${name} (e.g "replace=(\\d+)-(\\d+) $2-$1" - "12-34" -> "34-12"). Spaces
+	// inside the pattern have to be escaped as "\ " or written as \x20 or \s, and commas separate tags, so they have
+	// to be written as \x2c (e.g "replace=Mr\\.\\ (\\w+) $1" - "Mr. Smith" -> "Smith")
+	TagReplace = "replace"
+	//TagRemove removes the matches of a regular expression from a string value (e.g "remove=\\s*\\(.*?\\)" - "Sofia
+	// (BG)" -> "Sofia")
+	TagRemove = "remove"
+	//TagKeep keeps only the characters matching a character class and removes the rest (e.g "keep=0-9+" - "+359 (888)
+	// 123" -> "+359888123")
+	TagKeep = "keep"
+	//TagStripHTML removes all markup from a string value along with the content of elements such as script and style
+	// and decodes the entities (e.g "striphtml" - "<p>Tom &amp; Jerry</p><script>x()</script>" -> "Tom & Jerry")
+	TagStripHTML = "striphtml"
+	//TagEscapeHTML escapes the special HTML characters of a string value (e.g "escapehtml" - "<b>" -> "&lt;b&gt;")
+	TagEscapeHTML = "escapehtml"
+	//TagSanitizeHTML keeps only the elements and attributes allowed by a policy registered with RegisterHTMLPolicy
+	// (e.g "sanitizehtml=basic" - "<b onclick=\"x()\">bold</b><img src=x>" -> "<b>bold</b>" if the policy allows only
+	// the b element)
+	TagSanitizeHTML = "sanitizehtml"
+	//TagEmail trims and validates an email address, lowers its domain and converts it to punycode, optionally
+	// followed by EmailLowerLocal, EmailStripTag and EmailStripDots. Empty values are left intact (e.g "email" -
+	// " John@Bücher.Example " -> "John@xn--bcher-kva.example")
+	TagEmail = "email"
+	//TagPhone strips the formatting of a phone number and converts it to E.164 using the dialing prefixes of an
+	// optional default region, followed by PhonePassthrough. Empty values are left intact (e.g "phone=BG" -
+	// "0888 123 456" -> "+359888123456", "phone=US" - "(555) 123-4567" -> "+15551234567")
+	TagPhone = "phone"
+	//TagURL canonicalizes an absolute URL in a string or url.URL by lowering its scheme and host, converting the host
+	// to punycode, removing the default port and the dot segments, normalizing the percent-encoding and sorting the
+	// query, optionally followed by URLStripParams and URLStripFragment. Empty values are left intact (e.g "url" -
+	// "HTTP://Example.com:80/a/./b/../c?b=2&a=1" -> "http://example.com/a/c?a=1&b=2")
+	TagURL = "url"
+	//TagIP converts an IP address in a string or net.IP to its canonical form, writing IPv4-mapped IPv6 addresses as
+	// IPv4 (e.g "ip" - "2001:DB8:0:0::1" -> "2001:db8::1", "::ffff:192.0.2.1" -> "192.0.2.1")
+	TagIP = "ip"
+	//TagCIDR converts a network in a string or net.IPNet to its canonical form with the host bits cleared (e.g "cidr"
+	// - "192.0.2.15/24" -> "192.0.2.0/24")
+	TagCIDR = "cidr"
+	//TagMAC converts a hardware address in a string or a byte slice to lower hex octets separated by colons. A
+	// net.HardwareAddr field is only validated to have 6, 8 or 20 bytes and is never changed (e.g "mac" -
+	// "0123.4567.89AB" -> "01:23:45:67:89:ab")
+	TagMAC = "mac"
+	//TagHostname lowers a host name, removes its trailing dot and converts it to punycode (e.g "hostname" -
+	// "Bücher.Example." -> "xn--bcher-kva.example")
+	TagHostname = "hostname"
+	//TagMask replaces the characters of a string value with "*" or another mask character except for an optional
+	// number of first and last ones, masking everything if nothing would be hidden (e.g "mask=2 2" - "secret" ->
+	// "se**et", "mask=0 4 #" - "+359888123456" -> "#########3456")
+	TagMask = "mask"
+	//TagMaskEmail keeps the first character of an email address and its domain (e.g "maskemail" - "john@example.com"
+	// -> "j***@example.com")
+	TagMaskEmail = "maskemail"
+	//TagMaskCard masks all digits of a card number except for the last four and returns an error without the value
+	// if it fails the Luhn check (e.g "maskcard" - "4111 1111 1111 1111" -> "**** **** **** 1111")
+	TagMaskCard = "maskcard"
+	//TagRedact replaces a non-empty string value with "[REDACTED]" or the provided text (e.g "redact=hidden" -
+	// "secret" -> "hidden")
+	TagRedact = "redact"
+	//TagZero clears a field of any kind, including pointers, structs, slices and maps, ignoring the rest of its tags.
+	// Using it with a dedicated tag name set by WithTag gives a separate pass which produces e.g. a log-safe copy
+	// (e.g Password string 'log:"zero"')
+	TagZero = "zero"
+	//TagHash replaces a non-empty string value with its digest using one of the Hash algorithms, optionally followed
+	// by an encoding, which is EncodingHex by default (e.g "hash=sha256" - "value" -> "cd42404d52ad55cc...")
+	TagHash = "hash"
+	//TagHMAC replaces a non-empty string value with its HMAC using a key from the KeyProvider registered with
+	// RegisterKeyProvider, optionally followed by one of the Hash algorithms, which is HashSHA256 by default, and an
+	// encoding (e.g "hmac=users sha512 base64")
+	TagHMAC = "hmac"
+	//TagTokenize replaces a non-empty string value with a deterministic URL-safe token made of the first 16 bytes
+	// of its HMAC-SHA256 encoded as EncodingBase64URL, using a key from the registered KeyProvider (e.g
+	// "tokenize=users")
+	TagTokenize = "tokenize"
+	//TagEncrypt encrypts a non-empty string value with AES-GCM using the current version of a key from the
+	// registered KeyProvider, embedding the key ID so that TagDecrypt can find it after a rotation. The result is
+	// encoded as EncodingBase64URL and a value which is already encrypted with the key name is kept, so morphing a
+	// struct twice does not encrypt it twice (e.g "encrypt=pii")
+	TagEncrypt = "encrypt"
+	//TagDecrypt decrypts a non-empty string value encrypted by TagEncrypt with the same key name (e.g "decrypt=pii")
+	TagDecrypt = "decrypt"
+	//TagB64Encode encodes a string or byte slice as base64 using one of the Base64 variants, which is Base64Std by
+	// default (e.g "b64enc=rawurl" - "ab?" -> "YWI_")
+	TagB64Encode = "b64enc"
+	//TagB64Decode decodes a base64 string or byte slice using one of the Base64 variants, which is Base64Std by
+	// default (e.g "b64dec" - "YWI/" -> "ab?")
+	TagB64Decode = "b64dec"
+	//TagHexEncode encodes a string or byte slice as lower hex digits (e.g "hexenc" - "ab" -> "6162")
+	TagHexEncode = "hexenc"
+	//TagHexDecode decodes a hex string or byte slice (e.g "hexdec" - "6162" -> "ab")
+	TagHexDecode = "hexdec"
+	//TagURLEncode escapes a string or byte slice for a URL query, or for a path segment with URLEncodePath (e.g
+	// "urlenc" - "a b&c" -> "a+b%26c", "urlenc=path" - "a b&c" -> "a%20b&c")
+	TagURLEncode = "urlenc"
+	//TagURLDecode unescapes a string or byte slice escaped for a URL query, or for a path segment with URLEncodePath
+	// (e.g "urldec" - "a+b%26c" -> "a b&c")
+	TagURLDecode = "urldec"
+	//TagQPDecode decodes a quoted-printable string or byte slice, keeping invalid escapes as they are (e.g "qpdec" -
+	// "caf=C3=A9" -> "café")
+	TagQPDecode = "qpdec"
+	//TagJSON compacts a JSON document in a string or byte slice, or canonicalizes it with JSONCanonical (e.g "json" -
+	// "{ \"b\": 1.0, \"a\": 2 }" -> "{\"b\":1.0,\"a\":2}", "json=canonical" - "{ \"b\": 1.0, \"a\": 2 }" ->
+	// "{\"a\":2,\"b\":1}")
+	TagJSON = "json"
+	//TagNumeric parses a number in a string, optionally with NumericDecimalDot or NumericDecimalComma, and formats it
+	// with a dot, without digit groups and redundant zeros (e.g "numeric" - " 12,50 " -> "12.5", "1.234.567,0" ->
+	// "1234567", "numeric=dot" - "1,234" -> "1234")
+	TagNumeric = "numeric"
+	//TagBool replaces yes/no, on/off, y/n, t/f, 1/0 and true/false in any case with "true" or "false" (e.g "bool" -
+	// " Yes " -> "true", "off" -> "false")
+	TagBool = "bool"
+	//TagDate parses a date in a string and formats it using a Go layout, accepting the layouts after it separated by
+	// "|" and then common layouts such as RFC 3339, "2006-01-02", "2.1.2006" and "2 Jan 2006" (e.g "date=2006-01-02"
+	// - "15.06.2022" -> "2022-06-15", "date=2006-01-02|01/02/2006" - "06/15/2022" -> "2022-06-15")
+	TagDate = "date"
+	//TagTruncate truncates a string to a specified length of runes, optionally followed by a mode (TruncateBytes,
+	// TruncateRunes or TruncateGraphemes), TruncateWords and TruncateEllipsis, which is counted within the limit (e.g
+	// "truncate=3" - "value" -> "val", "truncate=9 words ellipsis" - "some value" -> "some…")
 	TagTruncate = "truncate"
-	//TagCeil performs ceiling on a floating number (e.g "ceil" - "1.45" -> "2.00")
+	//TagCeil performs ceiling on a floating or complex number, integers are left intact (e.g "ceil" - "1.45" -> "2.00")
 	TagCeil = "ceil"
-	//TagFloor performs flooring on a floating number (e.g "floor" - "1.65" -> "1.00")
+	//TagFloor performs flooring on a floating or complex number, integers are left intact (e.g "floor" - "1.65" -> "1.00")
 	TagFloor = "floor"
-	//TagRound performs rounding on a floating number (e.g "round" - "1.45" -> "1.00")
+	//TagRound performs rounding on a floating or complex number, integers are left intact (e.g "round" - "1.45" -> "1.00")
 	TagRound = "round"
-	//TagPrecision limits precision for a floating number (e.g "precision=2" - "1.499" -> "1.49")
+	//TagPrecision limits precision for a number using decimal arithmetic and an optional rounding mode, which defaults
+	// to RoundDown (e.g "precision=2" - "1.499" -> "1.49", "precision=2 half_up" - "1.005" -> "1.01")
 	TagPrecision = "precision"
+	//TagMin raises a number to a lower limit (e.g "min=0" - "-5" -> "0")
+	TagMin = "min"
+	//TagMax lowers a number to an upper limit (e.g "max=100" - "150" -> "100")
+	TagMax = "max"
+	//TagClamp limits a number to a range (e.g "clamp=0 100" - "150" -> "100", "-5" -> "0")
+	TagClamp = "clamp"
+	//TagAbs takes the absolute value of a number (e.g "abs" - "-5" -> "5")
+	TagAbs = "abs"
+	//TagMultiple rounds a number to the nearest multiple (e.g "multiple=5" - "12" -> "10", "13" -> "15")
+	TagMultiple = "multiple"
+	//TagRoundTo rounds a number to the given decimal places, negative values round to tens, hundreds, etc. (e.g
+	// "roundto=-2" - "1250" -> "1300", "roundto=1" - "1.25" -> "1.3")
+	TagRoundTo = "roundto"
+	//TagUTC converts a time.Time to UTC (e.g "utc" - "2022-01-01T12:00:00+02:00" -> "2022-01-01T10:00:00Z")
+	TagUTC = "utc"
+	//TagTimeZone converts a time.Time to an IANA time zone (e.g "tz=Europe/Sofia" - "2022-01-01T10:00:00Z" ->
+	// "2022-01-01T12:00:00+02:00")
+	TagTimeZone = "tz"
+	//TagTruncateTime rounds a time.Time down to a multiple of a duration since the zero time, which is in UTC, so days
+	// are better truncated with TagStartOfDay (e.g "truncate_time=1h" - "10:45:30" -> "10:00:00")
+	TagTruncateTime = "truncate_time"
+	//TagStartOfDay sets a time.Time to midnight in its own location (e.g "startofday" - "2022-01-01T10:45:00+02:00"
+	// -> "2022-01-01T00:00:00+02:00")
+	TagStartOfDay = "startofday"
+	//TagNotAfter lowers a time.Time to an upper limit, which is TimeNow, an RFC 3339 time or a date (e.g
+	// "notafter=now" - a time in the future -> time.Now(), "notafter=2022-01-01" - "2023-05-01" -> "2022-01-01")
+	TagNotAfter = "notafter"
+	//TagStripMonotonic removes the monotonic clock reading of a time.Time, so that it can be compared with == (e.g
+	// "strip_monotonic" - time.Now() -> time.Now().Round(0))
+	TagStripMonotonic = "strip_monotonic"
+	//TagDurationRound rounds a time.Duration to the nearest multiple of a duration (e.g "dround=1s" - 1.6s -> 2s)
+	TagDurationRound = "dround"
+	//TagDurationMin raises a time.Duration to a lower limit (e.g "dmin=1s" - 100ms -> 1s)
+	TagDurationMin = "dmin"
+	//TagDurationMax lowers a time.Duration to an upper limit (e.g "dmax=1m" - 1h -> 1m)
+	TagDurationMax = "dmax"
+	//TagDefault sets a value to a zero field and allocates nil pointers (e.g "default=unknown" - "" -> "unknown",
+	// "default=1h" - 0 -> time.Hour, "default=now" - time.Time{} -> time.Now())
+	TagDefault = "default"
+	//TagUnique removes the repeated items of a slice, keeping the first ones in their order, and compares pointers
+	// and structs by their content (e.g "unique" - ["b", "a", "b"] -> ["b", "a"], "dive,lower,exit,unique" -
+	// ["A", "a"] -> ["a"])
+	TagUnique = "unique"
+	//TagSort sorts a slice of strings, numbers, booleans or time.Time values in ascending order, keeping the order of
+	// equal items (e.g "sort" - ["b", "c", "a"] -> ["a", "b", "c"])
+	TagSort = "sort"
+	//TagSortDesc sorts a slice like TagSort in descending order (e.g "sortdesc" - [2, 3, 1] -> [3, 2, 1])
+	TagSortDesc = "sortdesc"
+	//TagCompact removes the zero items of a slice, including empty slices and maps (e.g "compact" - ["a", "", "b"] ->
+	// ["a", "b"], "dive,trim,exit,compact" - ["a", " "] -> ["a"])
+	TagCompact = "compact"
+	//TagLimit keeps up to a number of items at the beginning of a slice (e.g "limit=2" - [1, 2, 3] -> [1, 2])
+	TagLimit = "limit"
+	//TagReverse reverses the order of the items of a slice (e.g "reverse" - [1, 2, 3] -> [3, 2, 1])
+	TagReverse = "reverse"
+	//TagNonNil replaces a nil slice or map with an empty one and allocates nil pointers (e.g "nonnil" - nil -> [])
+	TagNonNil = "nonnil"
+	//TagAllowKeys keeps only the entries of a map with string or integer keys which are listed separated by spaces,
+	// comparing integer keys in decimal (e.g "allowkeys=a b" - {"a": 1, "c": 2} -> {"a": 1})
+	TagAllowKeys = "allowkeys"
+	//TagDenyKeys removes the entries of a map with string or integer keys which are listed separated by spaces,
+	// comparing integer keys in decimal (e.g "denykeys=a b" - {"a": 1, "c": 2} -> {"c": 2})
+	TagDenyKeys = "denykeys"
+	//TagDropEmpty removes the entries of a map with zero values, including empty slices and maps (e.g "dropempty" -
+	// {"a": "", "b": "value"} -> {"b": "value"}, "dive,trim,exit,dropempty" - {"a": " "} -> {})
+	TagDropEmpty = "dropempty"
+	//TagKeyPrefix prepends a prefix to the keys of a map with string keys (e.g "keyprefix=x_" - {"a": 1} ->
+	// {"x_a": 1})
+	TagKeyPrefix = "keyprefix"
+	//TagStripKeyPrefix removes a prefix from the keys of a map with string keys, keeping the value of the last of the
+	// keys stripped into the same one in sorted order (e.g "stripkeyprefix=x_" - {"x_a": 1, "b": 2} -> {"a": 1, "b": 2})
+	TagStripKeyPrefix = "stripkeyprefix"
+	//TagMaxKeys keeps up to a number of entries of a map with the first keys in sorted order (e.g "maxkeys=1" -
+	// {"b": 2, "a": 1} -> {"a": 1})
+	TagMaxKeys = "maxkeys"
+)
+
+// rounding modes for TagPrecision
+const (
+	//RoundHalfUp rounds to the nearest neighbour and ties away from zero (e.g. "1.005" -> "1.01", "-1.005" -> "-1.01")
+	RoundHalfUp = "half_up"
+	//RoundHalfEven rounds to the nearest neighbour and ties to the even one (e.g. "1.005" -> "1.00", "1.015" -> "1.02")
+	RoundHalfEven = "half_even"
+	//RoundDown rounds towards zero (e.g. "1.009" -> "1.00", "-1.009" -> "-1.00")
+	RoundDown = "down"
+	//RoundUp rounds away from zero (e.g. "1.001" -> "1.01", "-1.001" -> "-1.01")
+	RoundUp = "up"
+	//RoundCeil rounds towards positive infinity (e.g. "1.001" -> "1.01", "-1.009" -> "-1.00")
+	RoundCeil = "ceil"
+	//RoundFloor rounds towards negative infinity (e.g. "1.009" -> "1.00", "-1.001" -> "-1.01")
+	RoundFloor = "floor"
+)
+
+// options for TagTrim
+const (
+	//TrimZeroWidth trims zero-width characters such as U+200B and U+FEFF along with the whitespaces (e.g
+	// "trim=zerowidth" - "\u200b value " -> "value")
+	TrimZeroWidth = "zerowidth"
+)
+
+// options for TagNotAfter
+const (
+	//TimeNow sets the limit to the current time of each transformation (e.g. "notafter=now")
+	TimeNow = "now"
+)
+
+// options for TagTruncate
+const (
+	//TruncateBytes limits the length in bytes without splitting a rune (e.g. "truncate=3 bytes" - "ябълка" -> "я")
+	TruncateBytes = "bytes"
+	//TruncateRunes limits the length in runes (e.g. "truncate=3 runes" - "ябълка" -> "ябъ")
+	TruncateRunes = "runes"
+	//TruncateGraphemes limits the length in user-perceived characters keeping combined emojis and accents intact
+	TruncateGraphemes = "graphemes"
+	//TruncateWords cuts at the last whitespace before the limit if there is one (e.g. "truncate=7 words" - "some
+	// value" -> "some")
+	TruncateWords = "words"
+	//TruncateEllipsis appends "…" or the provided suffix if the value is truncated (e.g. "truncate=5 ellipsis=..." -
+	// "some value" -> "so...")
+	TruncateEllipsis = "ellipsis"
+)
+
+// options for TagEmail
+const (
+	//EmailLowerLocal lowers the local part of the address, which is case-sensitive by the standard but not by most
+	// providers (e.g. "email=lower" - "John@example.com" -> "john@example.com")
+	EmailLowerLocal = "lower"
+	//EmailStripTag removes the "+tag" suffix of the local part (e.g. "email=striptag" - "john+news@example.com" ->
+	// "john@example.com")
+	EmailStripTag = "striptag"
+	//EmailStripDots removes the dots from the local part of the addresses of providers which ignore them, such as
+	// gmail.com (e.g. "email=stripdots" - "j.o.h.n@gmail.com" -> "john@gmail.com")
+	EmailStripDots = "stripdots"
+)
+
+// options for TagPhone
+const (
+	//PhonePassthrough leaves the numbers which cannot be parsed intact instead of returning an error (e.g.
+	// "phone=US passthrough" - "call me" -> "call me")
+	PhonePassthrough = "passthrough"
+)
+
+// options for TagURL
+const (
+	//URLStripParams removes the query parameters with the listed names separated by "|", where a trailing "*" matches
+	// any suffix (e.g. "url=strip=utm_*|fbclid" - "http://example.com/?utm_source=x&id=1" -> "http://example.com/?id=1")
+	URLStripParams = "strip"
+	//URLStripFragment removes the fragment (e.g. "url=nofragment" - "http://example.com/#top" -> "http://example.com/")
+	URLStripFragment = "nofragment"
+)
+
+// algorithms for TagHash and TagHMAC
+const (
+	HashSHA224 = "sha224"
+	HashSHA256 = "sha256"
+	HashSHA384 = "sha384"
+	HashSHA512 = "sha512"
+)
+
+// encodings for TagHash and TagHMAC
+const (
+	//EncodingHex encodes the digest as lower hex digits
+	EncodingHex = "hex"
+	//EncodingBase64 encodes the digest using the standard base64 alphabet with padding
+	EncodingBase64 = "base64"
+	//EncodingBase64URL encodes the digest using the URL-safe base64 alphabet without padding
+	EncodingBase64URL = "base64url"
+)
+
+// variants for TagB64Encode and TagB64Decode
+const (
+	//Base64Std uses the standard alphabet with padding
+	Base64Std = "std"
+	//Base64URL uses the URL-safe alphabet with padding
+	Base64URL = "url"
+	//Base64RawStd uses the standard alphabet without padding
+	Base64RawStd = "rawstd"
+	//Base64RawURL uses the URL-safe alphabet without padding
+	Base64RawURL = "rawurl"
+)
+
+// components for TagURLEncode and TagURLDecode
+const (
+	//URLEncodeQuery escapes a query parameter, where spaces are written as "+"
+	URLEncodeQuery = "query"
+	//URLEncodePath escapes a path segment, where spaces are written as "%20"
+	URLEncodePath = "path"
+)
+
+// options for TagJSON
+const (
+	//JSONCanonical sorts the keys of the objects and writes the numbers in their shortest exact decimal form, using
+	// an exponent only for very large and very small values (e.g. "json=canonical" - "[1.50E+2, -0.0]" -> "[150,0]")
+	JSONCanonical = "canonical"
+)
+
+// options for TagNumeric
+const (
+	//NumericDecimalDot treats dots as decimal and commas as group separators (e.g. "numeric=dot" - "1,234.5" ->
+	// "1234.5")
+	NumericDecimalDot = "dot"
+	//NumericDecimalComma treats commas as decimal and dots as group separators (e.g. "numeric=comma" - "1.234" ->
+	// "1234")
+	NumericDecimalComma = "comma"
+)
+
+// key collision policies for TagKeys
+const (
+	//KeysKeepLast keeps the value of the last of the original keys in sorted order, which is the default (e.g.
+	// "keys=keeplast,lower" - {"A": 1, "a": 2} -> {"a": 2})
+	KeysKeepLast = "keeplast"
+	//KeysKeepFirst keeps the value of the first of the original keys in sorted order (e.g. "keys=keepfirst,lower" -
+	// {"A": 1, "a": 2} -> {"a": 1})
+	KeysKeepFirst = "keepfirst"
+	//KeysError fails when keys are morphed into the same key (e.g. "keys=error,lower" - {"A": 1, "a": 2} -> error)
+	KeysError = "error"
+	//KeysMerge merges the values using a KeyMerger registered with RegisterKeyMerger (e.g. "keys=merge=sum,lower" -
+	// {"A": 1, "a": 2} -> {"a": 3})
+	KeysMerge = "merge"
+)
+
+// options for TagSlug
+const (
+	//SlugSeparator sets the separator between words, which is "-" by default (e.g. "slug=sep=_" - "Some Title" ->
+	// "some_title")
+	SlugSeparator = "sep"
+	//SlugMaxLength limits the length of the slug in runes without cutting words (e.g. "slug=max=10" - "Some Long
+	// Title" -> "some-long")
+	SlugMaxLength = "max"
+	//SlugUnicode keeps the letters of other scripts instead of transliterating them (e.g. "slug=unicode" - "Здравей,
+	// Свят!" -> "здравей-свят")
+	SlugUnicode = "unicode"
+	//SlugStopWords removes the listed words separated by "|" unless the slug would be empty (e.g. "slug=stop=a|the" -
+	// "The Tale of a Town" -> "tale-of-town")
+	SlugStopWords = "stop"
+)
+
+// options for TagFrom and TagDefaultFrom
+const (
+	//FromLocalPart copies the part of a string before its last "@", or the whole string if there is none (e.g.
+	// "default_from=Email localpart" - "john.doe@example.com" -> "john.doe")
+	FromLocalPart = "localpart"
+	//FromDomain copies the part of a string after its last "@", or nothing if there is none (e.g. "from=Email
+	// domain" - "john.doe@example.com" -> "example.com")
+	FromDomain = "domain"
 )
 
 // navigational tags
@@ -56,17 +479,36 @@ const (
 	// otherwise neglected - e.g. SomeData []string 'morph:"dive,trim"' - goes inside the array and trims all values
 	TagDive = "dive"
 	//TagKeys enters keys of a map to perform transformations on them - e.g. SomeData map[string]string
-	// 'morph:"dive,keys,trim"' - goes inside the map and dives into its keys to trim them all
+	// 'morph:"dive,keys,trim"' - goes inside the map and dives into its keys to trim them all. The keys are morphed in
+	// their sorted order and the values of the keys morphed into the same one are resolved using KeysKeepLast,
+	// KeysKeepFirst, KeysError or KeysMerge - e.g. 'morph:"dive,keys=keepfirst,trim"'
 	TagKeys = "keys"
 	//TagExit states ending of the keys' transformations - e.g. SomeData map[string]string
 	// 'morph:"dive,keys,trim,exit,trim"' - goes inside the map and dives into its keys to trim them all after which
-	// it exits the keys and trims the values
+	// it exits the keys and trims the values. Used after the transformations of the items it exits TagDive, so that
+	// the following ones are applied to the collection - e.g. SomeData []string 'morph:"dive,trim,exit,unique"' -
+	// trims all values and then removes the repeated ones
 	TagExit = "exit"
 	//TagIgnore ignores a field of type struct and doesn't perform its underlying transformations - e.g. SomeData
 	// SomeStruct 'morph:"-"' - ignores this field and doesn't perform its internal morphing
 	TagIgnore = "-"
 )
 
+// cross-field tags
+const (
+	//TagFrom copies the value of a sibling field after it has been morphed, optionally followed by FromLocalPart or
+	// FromDomain to copy only a part of a string (e.g. Slug string 'morph:"from=Title,lower"' - copies the morphed
+	// Title into Slug and lowers it)
+	TagFrom = "from"
+	//TagDefaultFrom copies the value of a sibling field only if the field has a zero value, optionally followed by
+	// FromLocalPart or FromDomain like TagFrom (e.g. DisplayName string 'morph:"default_from=Email localpart"' - uses
+	// the local part of the morphed Email if DisplayName is empty)
+	TagDefaultFrom = "default_from"
+	//TagConcat joins the non-empty values of the listed string fields with a space (e.g. SearchText string
+	// 'morph:"concat=First Last"' - "John" and "Doe" -> "John Doe")
+	TagConcat = "concat"
+)
+
 const (
 	//DefaultTag is the tag used for morphing fields if no other tag is specified.
 	DefaultTag = "morph"
@@ -83,6 +525,12 @@ var navigationalTags = map[string]bool{
 	TagIgnore: true,
 }
 
+var crossFieldTags = map[string]bool{
+	TagFrom:        true,
+	TagDefaultFrom: true,
+	TagConcat:      true,
+}
+
 // Morph transforms the data of a given struct according to a set of provided tags
 type Morph interface {
 
@@ -90,20 +538,117 @@ type Morph interface {
 	//them using the provided tags.
 	//
 	//	Transformational tags:
-	//		'trim'      - TagTrim
-	//		'lower'     - TagLower
-	//		'upper'     - TagUpper
-	//		'truncate'  - TagTruncate
-	//		'ceil'      - TagCeil
-	//		'floor'     - TagFloor
-	//		'round'     - TagRound
-	//		'precision' - TagPrecision
+	//		'trim'            - TagTrim
+	//		'ltrim'           - TagLTrim
+	//		'rtrim'           - TagRTrim
+	//		'trimprefix'      - TagTrimPrefix
+	//		'trimsuffix'      - TagTrimSuffix
+	//		'squish'          - TagSquish
+	//		'nospace'         - TagNoSpace
+	//		'nozerowidth'     - TagNoZeroWidth
+	//		'nbsp'            - TagNBSP
+	//		'eol'             - TagEOL
+	//		'lower'           - TagLower
+	//		'upper'           - TagUpper
+	//		'title'           - TagTitle
+	//		'sentence'        - TagSentence
+	//		'camel'           - TagCamel
+	//		'pascal'          - TagPascal
+	//		'snake'           - TagSnake
+	//		'kebab'           - TagKebab
+	//		'constant'        - TagConstant
+	//		'nfc'             - TagNFC
+	//		'nfd'             - TagNFD
+	//		'nfkc'            - TagNFKC
+	//		'nfkd'            - TagNFKD
+	//		'casefold'        - TagCaseFold
+	//		'ascii'           - TagASCII
+	//		'confusables'     - TagConfusables
+	//		'slug'            - TagSlug
+	//		'replace'         - TagReplace
+	//		'remove'          - TagRemove
+	//		'keep'            - TagKeep
+	//		'striphtml'       - TagStripHTML
+	//		'escapehtml'      - TagEscapeHTML
+	//		'sanitizehtml'    - TagSanitizeHTML
+	//		'email'           - TagEmail
+	//		'phone'           - TagPhone
+	//		'url'             - TagURL
+	//		'ip'              - TagIP
+	//		'cidr'            - TagCIDR
+	//		'mac'             - TagMAC
+	//		'hostname'        - TagHostname
+	//		'mask'            - TagMask
+	//		'maskemail'       - TagMaskEmail
+	//		'maskcard'        - TagMaskCard
+	//		'redact'          - TagRedact
+	//		'zero'            - TagZero
+	//		'hash'            - TagHash
+	//		'hmac'            - TagHMAC
+	//		'tokenize'        - TagTokenize
+	//		'encrypt'         - TagEncrypt
+	//		'decrypt'         - TagDecrypt
+	//		'b64enc'          - TagB64Encode
+	//		'b64dec'          - TagB64Decode
+	//		'hexenc'          - TagHexEncode
+	//		'hexdec'          - TagHexDecode
+	//		'urlenc'          - TagURLEncode
+	//		'urldec'          - TagURLDecode
+	//		'qpdec'           - TagQPDecode
+	//		'json'            - TagJSON
+	//		'numeric'         - TagNumeric
+	//		'bool'            - TagBool
+	//		'date'            - TagDate
+	//		'truncate'        - TagTruncate
+	//		'ceil'            - TagCeil
+	//		'floor'           - TagFloor
+	//		'round'           - TagRound
+	//		'precision'       - TagPrecision
+	//		'min'             - TagMin
+	//		'max'             - TagMax
+	//		'clamp'           - TagClamp
+	//		'abs'             - TagAbs
+	//		'multiple'        - TagMultiple
+	//		'roundto'         - TagRoundTo
+	//		'utc'             - TagUTC
+	//		'tz'              - TagTimeZone
+	//		'truncate_time'   - TagTruncateTime
+	//		'startofday'      - TagStartOfDay
+	//		'notafter'        - TagNotAfter
+	//		'strip_monotonic' - TagStripMonotonic
+	//		'dround'          - TagDurationRound
+	//		'dmin'            - TagDurationMin
+	//		'dmax'            - TagDurationMax
+	//		'default'         - TagDefault
+	//		'unique'          - TagUnique
+	//		'sort'            - TagSort
+	//		'sortdesc'        - TagSortDesc
+	//		'compact'         - TagCompact
+	//		'limit'           - TagLimit
+	//		'reverse'         - TagReverse
+	//		'nonnil'          - TagNonNil
+	//		'allowkeys'       - TagAllowKeys
+	//		'denykeys'        - TagDenyKeys
+	//		'dropempty'       - TagDropEmpty
+	//		'keyprefix'       - TagKeyPrefix
+	//		'stripkeyprefix'  - TagStripKeyPrefix
+	//		'maxkeys'         - TagMaxKeys
 	//
 	//	Navigational tags:
-	//		'-'        - TagIgnore
-	//		'dive'     - TagDive
-	//		'keys'     - TagKeys
-	//		'exit'     - TagExit
+	//		'-'    - TagIgnore
+	//		'dive' - TagDive
+	//		'keys' - TagKeys
+	//		'exit' - TagExit
+	//
+	//	Cross-field tags:
+	//		'from'         - TagFrom
+	//		'default_from' - TagDefaultFrom
+	//		'concat'       - TagConcat
+	//
+	//	Fields referenced by cross-field tags are morphed before the fields depending on them. Cyclic dependencies
+	//	are reported as an error.
+	//
+	//	The string transformations are applied to []byte and json.RawMessage fields as well.
 	//
 	//	An example would be:
 	//
@@ -125,6 +670,7 @@ type Morph interface {
 	//		Numbers      []float64 `morph:"dive,precision=2"`
 	//		OtherNumbers []float64 `morph:"dive,floor"`
 	//		SomeMap		 map[string]string `morph:"dive,keys,trim,exit,trim"`
+	//		Labels       []string `morph:"dive,trim,lower,exit,compact,unique,sort"`
 	//		SomeOtherMap map[string]InnerModel `morph:"dive,keys,trim,exit"`
 	//	}
 	//
@@ -137,7 +683,7 @@ type Morph interface {
 
 	// Register accepts custom transformational tags or overrides existing ones and associates the provided
 	// transformation function with them.
-	// Navigational tags are reserved and are not subject of override. In such case an error will be returned.
+	// Navigational and cross-field tags are reserved and are not subject of override. In such case an error will be returned.
 	//
 	//	Example:
 	//		type Model struct {
@@ -153,8 +699,67 @@ type Morph interface {
 	//		morph.Struct(&data)
 	Register(tag string, transformer FieldTransformer) error
 
-	// WithTag changes the default tag set using DefaultTag to the specified tag if it is valid, otherwise it panics.
-	// Valid tags are anything but whitespace.
+	// RegisterHTMLPolicy adds a named policy for TagSanitizeHTML or replaces an existing one. Policies have to be
+	// registered before the structs using them are morphed for the first time, otherwise an error will be returned.
+	//
+	//	Example:
+	//		type Model struct {
+	//			Comment string `morph:"sanitizehtml=comments"`
+	//		}
+	//
+	//		morph := New()
+	//		morph.RegisterHTMLPolicy("comments", HTMLPolicy{
+	//			Elements: map[string][]string{"a": {"href"}, "b": nil, "i": nil, "p": nil},
+	//		})
+	RegisterHTMLPolicy(name string, policy HTMLPolicy) error
+
+	// RegisterKeyProvider sets the provider of the keys referenced by name in tags such as TagEncrypt, replacing the
+	// previous one. The key names are validated when a struct is morphed for the first time, so the provider has to
+	// be registered before that.
+	//
+	//	Example:
+	//		type Model struct {
+	//			Email string `store:"encrypt=pii" load:"decrypt=pii"`
+	//		}
+	//
+	//		store := New().WithTag("store")
+	//		store.RegisterKeyProvider(StaticKeys{"pii": key})
+	RegisterKeyProvider(provider KeyProvider) error
+
+	// RegisterLeafType declares the struct type of a value, or of the value a pointer points to, as a leaf, so that
+	// its fields are not morphed and the tags of the fields holding it are applied to the whole value instead, as
+	// they are for time.Time, big.Int, url.URL, etc. A type defined from time.Duration has to be registered too, so
+	// that TagDurationRound, TagDurationMin, TagDurationMax and TagDefault accept it, because it cannot be told apart
+	// from a type defined from int64.
+	//
+	//	Example:
+	//		type Model struct {
+	//			Price decimal.Decimal `morph:"round_price"`
+	//		}
+	//
+	//		morph := New()
+	//		morph.RegisterLeafType(decimal.Decimal{})
+	//		morph.Register("round_price", roundPriceTransformer)
+	RegisterLeafType(value interface{}) error
+
+	// RegisterKeyMerger adds or replaces a function merging the values of map keys which are morphed into the same
+	// key, which is referenced by name with KeysMerge. The names are validated when a struct is morphed for the first
+	// time, so the mergers have to be registered before that.
+	//
+	//	Example:
+	//		type Model struct {
+	//			Counts map[string]int `morph:"dive,keys=merge=sum,lower,exit"`
+	//		}
+	//
+	//		morph := New()
+	//		morph.RegisterKeyMerger("sum", func(_, merged, value interface{}) (interface{}, error) {
+	//			return merged.(int) + value.(int), nil
+	//		})
+	RegisterKeyMerger(name string, merger KeyMerger) error
+
+	// WithTag returns an instance reading the specified tag instead of DefaultTag if it is valid, otherwise it panics.
+	// Valid tags are anything but whitespace. The new instance shares everything registered with the one it was
+	// created from, which keeps reading its own tag.
 	//
 	//	Example:
 	//		type Model struct {
@@ -168,28 +773,242 @@ type Morph interface {
 // New creates an instance of Morph with default tags (e.g. TagTrim, TagLower..., etc.)
 func New() Morph {
 	lock := sync.RWMutex{}
+	policies := make(map[string]*htmlPolicy)
+	keys := &keyRing{mutex: &lock}
+	leaves := &leafRegistry{make(map[reflect.Type]bool), &lock}
 	return &morpher{
 		&cache{
 			DefaultTag,
 			map[string]FieldTransformer{
-				TagTrim:  new(trimTransformer),
-				TagLower: new(toLowerTransformer),
-				TagUpper: new(toUpperTransformer),
-				TagTruncate: &truncateTransformer{
-					NewIntParamsTransformer(&lock),
+				TagTrim: &trimTransformer{
+					NewStringParamsTransformer(&lock),
+				},
+				TagLTrim: &leftTrimTransformer{
+					NewStringParamsTransformer(&lock),
+				},
+				TagRTrim: &rightTrimTransformer{
+					NewStringParamsTransformer(&lock),
+				},
+				TagTrimPrefix: &trimPrefixTransformer{
+					NewStringParamsTransformer(&lock),
+				},
+				TagTrimSuffix: &trimSuffixTransformer{
+					NewStringParamsTransformer(&lock),
+				},
+				TagSquish:      new(squishTransformer),
+				TagNoSpace:     new(noSpaceTransformer),
+				TagNoZeroWidth: new(noZeroWidthTransformer),
+				TagNBSP:        new(nonBreakingSpaceTransformer),
+				TagEOL: &endOfLineTransformer{
+					NewStringParamsTransformer(&lock),
 				},
-				TagCeil:  new(ceilTransformer),
-				TagFloor: new(floorTransformer),
-				TagRound: new(roundTransformer),
-				TagPrecision: &precisionTransformer{
+				TagLower: &toLowerTransformer{
+					NewStringParamsTransformer(&lock),
+				},
+				TagUpper: &toUpperTransformer{
+					NewStringParamsTransformer(&lock),
+				},
+				TagTitle:       new(titleTransformer),
+				TagSentence:    new(sentenceTransformer),
+				TagCamel:       new(camelTransformer),
+				TagPascal:      new(pascalTransformer),
+				TagSnake:       new(snakeTransformer),
+				TagKebab:       new(kebabTransformer),
+				TagConstant:    new(constantTransformer),
+				TagNFC:         &normalizationTransformer{form: TagNFC},
+				TagNFD:         &normalizationTransformer{form: TagNFD},
+				TagNFKC:        &normalizationTransformer{form: TagNFKC},
+				TagNFKD:        &normalizationTransformer{form: TagNFKD},
+				TagCaseFold:    new(caseFoldTransformer),
+				TagASCII:       new(asciiTransformer),
+				TagConfusables: new(confusablesTransformer),
+				TagSlug:        newSlugTransformer(&lock),
+				TagReplace:     newReplaceTransformer(&lock),
+				TagRemove:      newRemoveTransformer(&lock),
+				TagKeep:        newKeepTransformer(&lock),
+				TagStripHTML:   new(stripHTMLTransformer),
+				TagEscapeHTML:  new(escapeHTMLTransformer),
+				TagSanitizeHTML: &sanitizeHTMLTransformer{
+					NewStringParamsTransformer(&lock),
+					policies,
+				},
+				TagEmail:     newEmailTransformer(&lock),
+				TagPhone:     newPhoneTransformer(&lock),
+				TagURL:       newURLTransformer(&lock),
+				TagIP:        new(ipTransformer),
+				TagCIDR:      new(cidrTransformer),
+				TagMAC:       new(macTransformer),
+				TagHostname:  new(hostnameTransformer),
+				TagMask:      newMaskTransformer(&lock),
+				TagMaskEmail: new(maskEmailTransformer),
+				TagMaskCard:  new(maskCardTransformer),
+				TagRedact:    &redactTransformer{NewStringParamsTransformer(&lock)},
+				TagZero:      new(zeroTransformer),
+				TagHash:      newHashTransformer(&lock),
+				TagHMAC:      newHMACTransformer(&lock, keys, TagHMAC),
+				TagTokenize:  newHMACTransformer(&lock, keys, TagTokenize),
+				TagEncrypt:   newCipherTransformer(&lock, keys, TagEncrypt),
+				TagDecrypt:   newCipherTransformer(&lock, keys, TagDecrypt),
+				TagB64Encode: newBase64Transformer(&lock, TagB64Encode),
+				TagB64Decode: newBase64Transformer(&lock, TagB64Decode),
+				TagHexEncode: new(hexEncodeTransformer),
+				TagHexDecode: new(hexDecodeTransformer),
+				TagURLEncode: newURLEncodingTransformer(&lock, TagURLEncode),
+				TagURLDecode: newURLEncodingTransformer(&lock, TagURLDecode),
+				TagQPDecode:  new(quotedPrintableDecodeTransformer),
+				TagJSON:      &jsonTransformer{NewStringParamsTransformer(&lock)},
+				TagNumeric:   newNumericTransformer(&lock),
+				TagBool:      new(boolTransformer),
+				TagDate:      newDateTransformer(&lock),
+				TagTruncate:  newTruncateTransformer(&lock),
+				TagCeil:      new(ceilTransformer),
+				TagFloor:     new(floorTransformer),
+				TagRound:     new(roundTransformer),
+				TagPrecision: newPrecisionTransformer(&lock),
+				TagMin: &minTransformer{
+					NewNumbersParamsTransformer(&lock, TagMin, 1),
+				},
+				TagMax: &maxTransformer{
+					NewNumbersParamsTransformer(&lock, TagMax, 1),
+				},
+				TagClamp: &clampTransformer{
+					NewNumbersParamsTransformer(&lock, TagClamp, 2),
+				},
+				TagAbs: new(absTransformer),
+				TagMultiple: &multipleTransformer{
+					NewNumbersParamsTransformer(&lock, TagMultiple, 1),
+				},
+				TagRoundTo: &roundToTransformer{
 					NewIntParamsTransformer(&lock),
 				},
+				TagUTC:      new(utcTransformer),
+				TagTimeZone: newTimeZoneTransformer(&lock),
+				TagTruncateTime: &truncateTimeTransformer{
+					newDurationParamsTransformer(&lock, TagTruncateTime, true),
+				},
+				TagStartOfDay:     new(startOfDayTransformer),
+				TagNotAfter:       newNotAfterTransformer(&lock),
+				TagStripMonotonic: new(stripMonotonicTransformer),
+				TagDurationRound: &durationRoundTransformer{
+					newDurationParamsTransformer(&lock, TagDurationRound, true),
+					leaves,
+				},
+				TagDurationMin: &durationMinTransformer{
+					newDurationParamsTransformer(&lock, TagDurationMin, false),
+					leaves,
+				},
+				TagDurationMax: &durationMaxTransformer{
+					newDurationParamsTransformer(&lock, TagDurationMax, false),
+					leaves,
+				},
+				TagDefault: &defaultTransformer{
+					NewStringParamsTransformer(&lock),
+					leaves,
+				},
+				TagUnique:   new(uniqueTransformer),
+				TagSort:     &sortTransformer{tag: TagSort},
+				TagSortDesc: &sortTransformer{descending: true, tag: TagSortDesc},
+				TagCompact:  new(compactTransformer),
+				TagLimit:    newLimitTransformer(&lock),
+				TagReverse:  new(reverseTransformer),
+				TagNonNil:   new(nonNilTransformer),
+				TagAllowKeys: &allowKeysTransformer{
+					newKeysParamsTransformer(&lock, TagAllowKeys),
+				},
+				TagDenyKeys: &denyKeysTransformer{
+					newKeysParamsTransformer(&lock, TagDenyKeys),
+				},
+				TagDropEmpty: new(dropEmptyTransformer),
+				TagKeyPrefix: &keyPrefixTransformer{
+					newPrefixParamsTransformer(&lock, TagKeyPrefix),
+				},
+				TagStripKeyPrefix: &stripKeyPrefixTransformer{
+					newPrefixParamsTransformer(&lock, TagStripKeyPrefix),
+				},
+				TagMaxKeys: &maxKeysTransformer{
+					newCountParamsTransformer(&lock, TagMaxKeys),
+				},
 			},
 			make(map[string]*structCache),
 			&lock,
+			make(map[string]bool),
+			make(map[string]KeyMerger),
 		},
 		&lock,
+		policies,
+		keys,
+		leaves,
+	}
+}
+
+func (c *morpher) RegisterHTMLPolicy(name string, policy HTMLPolicy) error {
+	name = strings.TrimSpace(name)
+	if len(name) == 0 {
+		return newError(ErrInvalidPolicyName)
+	}
+
+	compiled, err := newHTMLPolicy(policy)
+	if err != nil {
+		return err
+	}
+
+	c.mutex.Lock()
+	c.policies[name] = compiled
+	c.mutex.Unlock()
+
+	return nil
+}
+
+func (c *morpher) RegisterKeyProvider(provider KeyProvider) error {
+	if provider == nil {
+		return newError(ErrInvalidKeyProvider)
+	}
+
+	c.mutex.Lock()
+	c.keys.provider = provider
+	c.mutex.Unlock()
+
+	return nil
+}
+
+func (c *morpher) RegisterLeafType(value interface{}) error {
+	valueType := reflect.TypeOf(value)
+	if valueType != nil && valueType.Kind() == reflect.Ptr {
+		valueType = valueType.Elem()
+	}
+
+	if valueType == nil || (valueType.Kind() != reflect.Struct && valueType.Kind() != reflect.Int64) {
+		return newError(ErrInvalidLeafType)
+	}
+
+	c.leaves.add(valueType)
+	return nil
+}
+
+func (c *morpher) RegisterKeyMerger(name string, merger KeyMerger) error {
+	name = strings.TrimSpace(name)
+	if len(name) == 0 {
+		return newError(ErrInvalidMergerName)
+	}
+
+	if merger == nil {
+		return newError(ErrInvalidMerger)
 	}
+
+	c.mutex.Lock()
+	c.cache.keyMergers[name] = merger
+	c.mutex.Unlock()
+
+	return nil
+}
+
+// isLeaf reports whether a struct type is a built-in or a registered leaf type
+func (c *morpher) isLeaf(valueType reflect.Type) bool {
+	if isLeafType(valueType) {
+		return true
+	}
+
+	return c.leaves.contains(valueType)
 }
 
 func (c *morpher) WithTag(tag string) Morph {
@@ -198,13 +1017,20 @@ func (c *morpher) WithTag(tag string) Morph {
 		panic(newError(ErrInvalidTagName))
 	}
 
-	c.cache.tagName = tag
-	return c
+	tagCache := *c.cache
+	tagCache.tagName = tag
+
+	tagMorpher := *c
+	tagMorpher.cache = &tagCache
+	return &tagMorpher
 }
 
 type morpher struct {
-	cache *cache
-	mutex *sync.RWMutex
+	cache    *cache
+	mutex    *sync.RWMutex
+	policies map[string]*htmlPolicy
+	keys     *keyRing
+	leaves   *leafRegistry
 }
 
 func (c *morpher) Register(tag string, transformer FieldTransformer) error {
@@ -213,7 +1039,7 @@ func (c *morpher) Register(tag string, transformer FieldTransformer) error {
 		return newError(ErrInvalidTagName)
 	}
 
-	if _, ok := navigationalTags[tag]; ok {
+	if navigationalTags[tag] || crossFieldTags[tag] {
 		return newErrorf(ErrReservedTagOverride, tag)
 	}
 
@@ -223,6 +1049,7 @@ func (c *morpher) Register(tag string, transformer FieldTransformer) error {
 
 	c.mutex.Lock()
 	c.cache.transformers[tag] = transformer
+	c.cache.customTags[tag] = true
 	c.mutex.Unlock()
 
 	return nil
@@ -256,7 +1083,7 @@ func (c *morpher) morphStruct(structValue *reflect.Value, structType reflect.Typ
 
 	for i := 0; i < strCache.fieldsLength; i++ {
 		field := *strCache.fields[i]
-		if err = c.morphField(structValue.Field(field.index), field.tags); err != nil {
+		if err = c.morphField(structValue.Field(field.index), field.tags, structValue); err != nil {
 			return err
 		}
 	}
@@ -264,29 +1091,46 @@ func (c *morpher) morphStruct(structValue *reflect.Value, structType reflect.Typ
 	return nil
 }
 
-func (c *morpher) morphField(fieldValue reflect.Value, tag *tagChainCache) (err error) {
+func (c *morpher) morphField(fieldValue reflect.Value, tag *tagChainCache, parent *reflect.Value) (err error) {
+	if replacer := getFieldReplacer(tag); replacer != nil && fieldValue.CanSet() {
+		return replacer.transformer.Transform(&fieldValue, replacer.paramsKey)
+	}
+
 	actualValue := getActualValue(&fieldValue)
 	actualKind := actualValue.Kind()
 
-	if actualKind == reflect.Struct {
+	if actualKind == reflect.Struct && !c.isLeaf(actualValue.Type()) {
 		return c.morphStruct(actualValue, actualValue.Type())
 	}
 
+	allocate := false
 	newValue := getAssignableValue(actualValue, &actualKind)
-	for currentTag := tag; currentTag != nil && err == nil; currentTag = currentTag.next {
+	for currentTag := tag; currentTag != nil && err == nil; currentTag = currentTag.following() {
 		if currentTag.tag == TagDive {
 			err = c.dive(actualValue, &actualKind, currentTag.next)
-			break
+			continue
+		}
+
+		if currentTag.sources != nil {
+			// the cross-field tags fill the field like TagDefault, so a nil pointer is allocated for a copied value
+			err = copyFromSources(parent, newValue, currentTag)
+			allocate = allocate || !newValue.IsZero()
+			continue
 		}
 
 		if currentTag.transformer == nil {
 			continue
 		}
 
-		err = currentTag.transformer.Transform(newValue, currentTag.paramsKey)
+		if tr, ok := currentTag.transformer.(allocator); ok {
+			allocate = allocate || tr.allocates()
+		}
+
+		err = transformValue(currentTag, newValue)
 	}
 
-	if err != nil || newValue != actualValue {
+	// nil pointers are only allocated by the transformers which ask for it, the others leave them nil
+	if err != nil || actualKind != reflect.Ptr || (actualValue.IsNil() && !allocate) {
 		return
 	}
 
@@ -300,6 +1144,8 @@ func (c *morpher) dive(actualValue *reflect.Value, actualKind *reflect.Kind, tag
 		return c.morphCollection(actualValue, tag)
 	case reflect.Map:
 		return c.morphMap(actualValue, tag)
+	case reflect.Ptr, reflect.Interface:
+		return nil // nil pointers and interfaces have no items
 	}
 
 	return newErrorf(ErrInvalidDiveFmt, actualKind.String())
@@ -308,36 +1154,61 @@ func (c *morpher) dive(actualValue *reflect.Value, actualKind *reflect.Kind, tag
 func (c *morpher) morphCollection(sliceValue *reflect.Value, tags *tagChainCache) (err error) {
 	itemsLength := sliceValue.Len()
 	for i := 0; i < itemsLength && err == nil; i++ {
-		err = c.morphField(sliceValue.Index(i), tags)
+		err = c.morphField(sliceValue.Index(i), tags, nil)
 	}
 
 	return
 }
 
 func (c *morpher) morphMap(mapValue *reflect.Value, tags *tagChainCache) error {
-	shouldMorphKeys := tags != nil && tags.tag == TagKeys && tags.keysChain != nil
-	for _, key := range mapValue.MapKeys() {
-		morphedValue := reflect.New(mapValue.Type().Elem()).Elem()
-		morphedValue.Set(mapValue.MapIndex(key))
+	keys := sortedMapKeys(mapValue)
+	if tags == nil || tags.tag != TagKeys || tags.keysChain == nil {
+		for _, key := range keys {
+			morphedValue := reflect.New(mapValue.Type().Elem()).Elem()
+			morphedValue.Set(mapValue.MapIndex(key))
 
-		if shouldMorphKeys {
-			mapValue.SetMapIndex(key, reflect.Value{}) // removes key to transform it
-			if err := c.morphMapKey(&key, tags.keysChain); err != nil {
-				return err
-			}
-			if err := c.morphField(morphedValue, tags.next); err != nil {
+			if err := c.morphField(morphedValue, tags, nil); err != nil {
 				return err
 			}
 
 			mapValue.SetMapIndex(key, morphedValue)
-			continue
 		}
 
-		if err := c.morphField(morphedValue, tags); err != nil {
+		return nil
+	}
+
+	// the morphed entries are collected separately, so that the ones of the original keys are not overwritten
+	morphedMap := reflect.MakeMapWithSize(mapValue.Type(), len(keys))
+	for _, key := range keys {
+		morphedValue := reflect.New(mapValue.Type().Elem()).Elem()
+		morphedValue.Set(mapValue.MapIndex(key))
+
+		if err := c.morphMapKey(&key, tags.keysChain); err != nil {
+			return err
+		}
+		if err := c.morphField(morphedValue, tags.next, nil); err != nil {
 			return err
 		}
 
-		mapValue.SetMapIndex(key, morphedValue)
+		if merged := morphedMap.MapIndex(key); merged.IsValid() {
+			resolved, err := tags.collision.resolve(key, merged, morphedValue)
+			if err != nil {
+				return err
+			}
+
+			morphedValue = resolved
+		}
+
+		morphedMap.SetMapIndex(key, morphedValue)
+	}
+
+	for _, key := range mapValue.MapKeys() {
+		mapValue.SetMapIndex(key, reflect.Value{})
+	}
+
+	iterator := morphedMap.MapRange()
+	for iterator.Next() {
+		mapValue.SetMapIndex(iterator.Key(), iterator.Value())
 	}
 
 	return nil
@@ -348,5 +1219,5 @@ func (c *morpher) morphMapKey(key *reflect.Value, tags *tagChainCache) error {
 	morphedKey.Set(*key)
 	*key = morphedKey
 
-	return c.morphField(*key, tags)
+	return c.morphField(*key, tags, nil)
 }
@@ -25,8 +25,17 @@
 package morph
 
 import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"net"
+	"net/url"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -885,378 +894,4688 @@ func Test_StructWithTagTrim_EmbeddedPrivateField(t *testing.T) {
 
 //endregion EmbeddedField
 
+func Test_StructWithTagTrimZeroWidth(t *testing.T) {
+	type testData struct {
+		String string `morph:"trim=zerowidth"`
+		Plain  string `morph:"trim"`
+	}
+
+	data := testData{
+		String: "\ufeff\u200b value\u00a0\u200b",
+		Plain:  "\u200b value\u00a0",
+	}
+
+	transformer := New()
+	err := transformer.Struct(&data)
+
+	require.Nil(t, err)
+	require.Equal(t, "value", data.String)
+	require.Equal(t, "\u200b value", data.Plain)
+}
+
+func Test_StructWithTagTrimInvalidParameter(t *testing.T) {
+	type testData struct {
+		String string `morph:"trim=baba"`
+	}
+
+	transformer := New()
+	err := transformer.Struct(&testData{})
+
+	require.Error(t, err)
+	require.Equal(t, "invalid parameters 'baba' for tag: 'trim'", err.Error())
+}
+
 //endregion trim
 
-//region upper
+//region whitespace
 
-func Test_StructWithTagUpper(t *testing.T) {
+func Test_StructWithTagLTrimRTrim(t *testing.T) {
 	type testData struct {
-		String string `morph:"upper"`
+		Left         string `morph:"ltrim"`
+		Right        string `morph:"rtrim"`
+		LeftCutset   string `morph:"ltrim=0"`
+		RightCutset  string `morph:"rtrim=!?"`
+		CombinedTrim string `morph:"ltrim,rtrim=.!"`
 	}
+
 	data := testData{
-		String: "data",
+		Left:         " \u200b value ",
+		Right:        " value \t\n",
+		LeftCutset:   "00700",
+		RightCutset:  "value!?!",
+		CombinedTrim: "  value.!",
 	}
 
 	transformer := New()
 	err := transformer.Struct(&data)
 
 	require.Nil(t, err)
-	require.Equal(t, "DATA", data.String)
+	require.Equal(t, "value ", data.Left)
+	require.Equal(t, " value", data.Right)
+	require.Equal(t, "700", data.LeftCutset)
+	require.Equal(t, "value", data.RightCutset)
+	require.Equal(t, "value", data.CombinedTrim)
 }
 
-//endregion upper
+func Test_StructWithTagTrimPrefixSuffix(t *testing.T) {
+	type testData struct {
+		Prefix string `morph:"trimprefix=+359"`
+		Suffix string `morph:"trimsuffix=.com"`
+		Both   string `morph:"trimprefix=www.,trimsuffix=/"`
+	}
 
-//region truncate
+	data := testData{
+		Prefix: "+359888",
+		Suffix: "example.com",
+		Both:   "www.example.com/",
+	}
 
-func Test_StructWithTagTruncateBadParameter(t *testing.T) {
+	transformer := New()
+	err := transformer.Struct(&data)
+
+	require.Nil(t, err)
+	require.Equal(t, "888", data.Prefix)
+	require.Equal(t, "example", data.Suffix)
+	require.Equal(t, "example.com", data.Both)
+}
+
+func Test_StructWithTagTrimPrefixMissingParameter(t *testing.T) {
 	type testData struct {
-		String string `morph:"truncate=baba"`
+		String string `morph:"trimprefix"`
+	}
+
+	transformer := New()
+	err := transformer.Struct(&testData{})
+
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "missing parameters")
+}
+
+func Test_StructWithTagSquishNoSpace(t *testing.T) {
+	type name string
+	type testData struct {
+		Squish  name   `morph:"squish"`
+		NoSpace string `morph:"nospace"`
+	}
+
+	data := testData{
+		Squish:  " some \t\n value\u00a0\u00a0here\u200b ",
+		NoSpace: "0888 123\u00a0456\t",
+	}
+
+	transformer := New()
+	err := transformer.Struct(&data)
+
+	require.Nil(t, err)
+	require.Equal(t, name("some value here"), data.Squish)
+	require.Equal(t, "0888123456", data.NoSpace)
+}
+
+func Test_StructWithTagNoZeroWidthNBSP(t *testing.T) {
+	type testData struct {
+		NoZeroWidth string `morph:"nozerowidth"`
+		NBSP        string `morph:"nbsp"`
+	}
+
+	data := testData{
+		NoZeroWidth: "\ufeffva\u200blue\u2060",
+		NBSP:        "some\u00a0value\u202fhere",
+	}
+
+	transformer := New()
+	err := transformer.Struct(&data)
+
+	require.Nil(t, err)
+	require.Equal(t, "value", data.NoZeroWidth)
+	require.Equal(t, "some value here", data.NBSP)
+}
+
+func Test_StructWithTagEOL(t *testing.T) {
+	type testData struct {
+		LF   string `morph:"eol"`
+		CRLF string `morph:"eol=crlf"`
+	}
+
+	data := testData{
+		LF:   "a\r\nb\rc\nd",
+		CRLF: "a\r\nb\rc\nd",
+	}
+
+	transformer := New()
+	err := transformer.Struct(&data)
+
+	require.Nil(t, err)
+	require.Equal(t, "a\nb\nc\nd", data.LF)
+	require.Equal(t, "a\r\nb\r\nc\r\nd", data.CRLF)
+}
+
+func Test_StructWithTagEOLInvalidParameter(t *testing.T) {
+	type testData struct {
+		String string `morph:"eol=cr"`
+	}
+
+	transformer := New()
+	err := transformer.Struct(&testData{})
+
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid parameters")
+}
+
+//endregion whitespace
+
+//region unicode
+
+func Test_StructWithTagNormalization(t *testing.T) {
+	type testData struct {
+		NFC  []string `morph:"dive,nfc"`
+		NFD  []string `morph:"dive,nfd"`
+		NFKC []string `morph:"dive,nfkc"`
+		NFKD []string `morph:"dive,nfkd"`
+	}
+
+	values := []string{"e\u0301", "\u00e9", "\ufb01", "\u1100\u1161\u11a8", "a\u0323\u0307", "\uff21\u0301"}
+	data := testData{
+		NFC:  append([]string{}, values...),
+		NFD:  append([]string{}, values...),
+		NFKC: append([]string{}, values...),
+		NFKD: append([]string{}, values...),
+	}
+
+	transformer := New()
+	err := transformer.Struct(&data)
+
+	require.Nil(t, err)
+	require.Equal(t, []string{"\u00e9", "\u00e9", "\ufb01", "\uac01", "\u1ea1\u0307", "\uff21\u0301"}, data.NFC)
+	require.Equal(t, []string{"e\u0301", "e\u0301", "\ufb01", "\u1100\u1161\u11a8", "a\u0323\u0307",
+		"\uff21\u0301"}, data.NFD)
+	require.Equal(t, []string{"\u00e9", "\u00e9", "fi", "\uac01", "\u1ea1\u0307", "\u00c1"}, data.NFKC)
+	require.Equal(t, []string{"e\u0301", "e\u0301", "fi", "\u1100\u1161\u11a8", "a\u0323\u0307", "A\u0301"},
+		data.NFKD)
+}
+
+func Test_StructWithTagNormalizationUniqueness(t *testing.T) {
+	type testData struct {
+		Composed   string `morph:"nfc"`
+		Decomposed string `morph:"nfc"`
+	}
+
+	data := testData{
+		Composed:   "Jos\u00e9",
+		Decomposed: "Jose\u0301",
+	}
+
+	transformer := New()
+	err := transformer.Struct(&data)
+
+	require.Nil(t, err)
+	require.Equal(t, data.Composed, data.Decomposed)
+}
+
+func Test_StructWithTagCaseFold(t *testing.T) {
+	type testData struct {
+		Strings []string `morph:"dive,casefold"`
+	}
+
+	data := testData{
+		Strings: []string{"Straße", "STRASSE", "ΣΊΣΥΦΟΣ", "σίσυφος", "Ǆ", "İ"},
+	}
+
+	transformer := New()
+	err := transformer.Struct(&data)
+
+	require.Nil(t, err)
+	require.Equal(t, data.Strings[0], data.Strings[1])
+	require.Equal(t, data.Strings[2], "σίσυφοσ")
+	require.Equal(t, data.Strings[3], "σίσυφοσ")
+	require.Equal(t, "ǆ", data.Strings[4])
+	require.Equal(t, "i\u0307", data.Strings[5])
+}
+
+func Test_StructWithTagASCII(t *testing.T) {
+	type testData struct {
+		Strings []string `morph:"dive,ascii"`
+	}
+
+	data := testData{
+		Strings: []string{"Ćevapčići в София", "Straße", "Ærøskøbing", "Юлия Щерева", "Αθήνα", "ﬁne 😀 ½"},
+	}
+
+	transformer := New()
+	err := transformer.Struct(&data)
+
+	require.Nil(t, err)
+	require.Equal(t, []string{"Cevapcici v Sofiya", "Strasse", "Aeroskobing", "Yuliya Shtereva", "Athina",
+		"fine  1/2"}, data.Strings)
+}
+
+func Test_StructWithTagConfusables(t *testing.T) {
+	type testData struct {
+		Latin    string `morph:"confusables"`
+		Cyrillic string `morph:"confusables"`
+		Wide     string `morph:"confusables"`
+		Digits   string `morph:"confusables"`
+		Other    string `morph:"confusables"`
+	}
+
+	data := testData{
+		Latin:    "paypal",
+		Cyrillic: "p\u0430yp\u0430l",
+		Wide:     "\uff50\uff41\uff59\uff50\uff41\uff4c",
+		Digits:   "0ff1ce",
+		Other:    "office",
+	}
+
+	transformer := New()
+	err := transformer.Struct(&data)
+
+	require.Nil(t, err)
+	require.Equal(t, data.Latin, data.Cyrillic)
+	require.Equal(t, data.Latin, data.Wide)
+	require.Equal(t, "0ff1ce", data.Digits)
+	require.NotEqual(t, data.Digits, data.Other)
+}
+
+//endregion unicode
+
+//region slug
+
+func Test_StructWithTagSlug(t *testing.T) {
+	type testData struct {
+		Default   []string `morph:"dive,slug"`
+		Separator string   `morph:"slug=sep=_"`
+		Unicode   string   `morph:"slug=unicode"`
+	}
+
+	data := testData{
+		Default:   []string{"Hello, World!", "  Don't   stop  ", "Здравей, Свят!", "Crème brûlée 2"},
+		Separator: "Some Title",
+		Unicode:   "Здравей, Свят!",
+	}
+
+	transformer := New()
+	err := transformer.Struct(&data)
+
+	require.Nil(t, err)
+	require.Equal(t, []string{"hello-world", "dont-stop", "zdravey-svyat", "creme-brulee-2"}, data.Default)
+	require.Equal(t, "some_title", data.Separator)
+	require.Equal(t, "здравей-свят", data.Unicode)
+}
+
+func Test_StructWithTagSlugMaxLength(t *testing.T) {
+	type testData struct {
+		Words    string `morph:"slug=max=10"`
+		Exact    string `morph:"slug=max=9"`
+		LongWord string `morph:"slug=max=5"`
+	}
+
+	data := testData{
+		Words:    "Some Long Title",
+		Exact:    "Some Long Title",
+		LongWord: "Supercalifragilistic title",
+	}
+
+	transformer := New()
+	err := transformer.Struct(&data)
+
+	require.Nil(t, err)
+	require.Equal(t, "some-long", data.Words)
+	require.Equal(t, "some-long", data.Exact)
+	require.Equal(t, "super", data.LongWord)
+}
+
+func Test_StructWithTagSlugStopWords(t *testing.T) {
+	type testData struct {
+		Title    string `morph:"slug=max=12 stop=a|the"`
+		OnlyStop string `morph:"slug=stop=a|the"`
+	}
+
+	data := testData{
+		Title:    "The Tale of a Town",
+		OnlyStop: "The A",
+	}
+
+	transformer := New()
+	err := transformer.Struct(&data)
+
+	require.Nil(t, err)
+	require.Equal(t, "tale-of-town", data.Title)
+	require.Equal(t, "the-a", data.OnlyStop)
+}
+
+func Test_StructWithTagSlugFromTitle(t *testing.T) {
+	type testData struct {
+		Slug  string `morph:"from=Title,slug"`
+		Title string `morph:"squish"`
+	}
+
+	data := testData{
+		Title: "  Some   Title ",
+	}
+
+	transformer := New()
+	err := transformer.Struct(&data)
+
+	require.Nil(t, err)
+	require.Equal(t, "Some Title", data.Title)
+	require.Equal(t, "some-title", data.Slug)
+}
+
+func Test_StructWithTagSlugInvalidParameters(t *testing.T) {
+	type testData struct {
+		String string `morph:"slug=max=baba"`
+	}
+
+	type otherData struct {
+		String string `morph:"slug=baba"`
+	}
+
+	transformer := New()
+
+	err := transformer.Struct(&testData{})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid parameters")
+
+	err = transformer.Struct(&otherData{})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "baba")
+}
+
+//endregion slug
+
+//region regexp
+
+func Test_StructWithTagReplace(t *testing.T) {
+	type testData struct {
+		Swap     string   `morph:"replace=(\\d+)-(\\d+) $2-$1"`
+		Named    string   `morph:"replace=(?P<user>\\w+)@\\w+ ${user}"`
+		Spaces   string   `morph:"replace=\\s+ _"`
+		Comma    string   `morph:"replace=\\x2c\\s* |"`
+		Space    string   `morph:"replace=Mr\\.\\ (\\w+) $1"`
+		Hex      string   `morph:"replace=(\\w+)\\x20(\\w+) $2 $1"`
+		Dive     []string `morph:"dive,replace=o 0"`
+		Pointer  *string  `morph:"replace=a b"`
+		Chained  string   `morph:"trim,replace=^0+,lower"`
+		Repeated string   `morph:"replace=a b,replace=b c"`
+	}
+
+	pointer := "banana"
+	data := testData{
+		Swap:     "12-34",
+		Named:    "john@example",
+		Spaces:   "a  b \t c",
+		Comma:    "a, b,c",
+		Space:    "Mr. Smith",
+		Hex:      "John Smith",
+		Dive:     []string{"foo", "boo"},
+		Pointer:  &pointer,
+		Chained:  " 007AB ",
+		Repeated: "aab",
+	}
+
+	transformer := New()
+	err := transformer.Struct(&data)
+
+	require.Nil(t, err)
+	require.Equal(t, "34-12", data.Swap)
+	require.Equal(t, "john", data.Named)
+	require.Equal(t, "a_b_c", data.Spaces)
+	require.Equal(t, "a|b|c", data.Comma)
+	require.Equal(t, "Smith", data.Space)
+	require.Equal(t, "Smith John", data.Hex)
+	require.Equal(t, []string{"f00", "b00"}, data.Dive)
+	require.Equal(t, "bbnbnb", *data.Pointer)
+	require.Equal(t, "7ab", data.Chained)
+	require.Equal(t, "ccc", data.Repeated)
+}
+
+func Test_StructWithTagRemove(t *testing.T) {
+	type testData struct {
+		Brackets string `morph:"remove=\\s*\\(.*?\\)"`
+		Digits   string `morph:"remove=\\d"`
+	}
+
+	data := testData{
+		Brackets: "Sofia (BG)",
+		Digits:   "a1b2c3",
+	}
+
+	transformer := New()
+	err := transformer.Struct(&data)
+
+	require.Nil(t, err)
+	require.Equal(t, "Sofia", data.Brackets)
+	require.Equal(t, "abc", data.Digits)
+}
+
+func Test_StructWithTagKeep(t *testing.T) {
+	type testData struct {
+		Phone   string `morph:"keep=0-9+"`
+		Class   string `morph:"keep=[a-z]"`
+		Letters string `morph:"keep=\\p{L}"`
+		Bracket string `morph:"keep=a\\]"`
+		Single  string `morph:"keep=x"`
+	}
+
+	data := testData{
+		Phone:   "+359 (888) 123",
+		Class:   "a-B-c",
+		Letters: "Здравей, Свят 2!",
+		Bracket: "[a] b",
+		Single:  "x-y-x",
+	}
+
+	transformer := New()
+	err := transformer.Struct(&data)
+
+	require.Nil(t, err)
+	require.Equal(t, "+359888123", data.Phone)
+	require.Equal(t, "ac", data.Class)
+	require.Equal(t, "ЗдравейСвят", data.Letters)
+	require.Equal(t, "a]", data.Bracket)
+	require.Equal(t, "xx", data.Single)
+}
+
+func Test_StructWithTagRegexpInvalidParameters(t *testing.T) {
+	type replaceData struct {
+		String string `morph:"replace=(a b"`
 	}
 
-	data := testData{
-		String: "123456",
+	type removeData struct {
+		String string `morph:"remove=[a"`
+	}
+
+	type keepData struct {
+		String string `morph:"keep=^a"`
+	}
+
+	type keepInjectionData struct {
+		String string `morph:"keep=a]|[b"`
+	}
+
+	type keepSuffixData struct {
+		String string `morph:"keep=a]b"`
+	}
+
+	type missingData struct {
+		String string `morph:"remove"`
+	}
+
+	transformer := New()
+
+	err := transformer.Struct(&replaceData{})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid parameters '(a' for tag: 'replace'")
+
+	err = transformer.Struct(&removeData{})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid parameters")
+
+	err = transformer.Struct(&keepData{})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid parameters")
+
+	err = transformer.Struct(&keepInjectionData{})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid parameters 'a]|[b' for tag: 'keep'")
+
+	err = transformer.Struct(&keepSuffixData{})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid parameters 'a]b' for tag: 'keep'")
+
+	err = transformer.Struct(&missingData{})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "remove")
+}
+
+func Test_StructWithTagReplaceNotAString(t *testing.T) {
+	type testData struct {
+		Int int `morph:"replace=1 2"`
+	}
+
+	transformer := New()
+	err := transformer.Struct(&testData{Int: 1})
+
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "replace")
+}
+
+//endregion regexp
+
+//region html
+
+func Test_StructWithTagStripHTML(t *testing.T) {
+	type testData struct {
+		Markup    string   `morph:"striphtml"`
+		Dropped   string   `morph:"striphtml"`
+		Broken    string   `morph:"striphtml"`
+		Text      []string `morph:"dive,striphtml,squish"`
+		Attribute string   `morph:"striphtml"`
+	}
+
+	data := testData{
+		Markup:    "<p class=\"intro\">Tom &amp; Jerry</p><!-- comment --><br/>",
+		Dropped:   "a<script>if (a < b) { x() }</script><style>p{}</style><template><b>c</b></template>b",
+		Broken:    "1 < 2 and <b>bold<i",
+		Text:      []string{"<p>Hello</p> <p>World</p>", "&lt;b&gt; &#169;"},
+		Attribute: "<a title=\"x > y\">link</a>",
+	}
+
+	transformer := New()
+	err := transformer.Struct(&data)
+
+	require.Nil(t, err)
+	require.Equal(t, "Tom & Jerry", data.Markup)
+	require.Equal(t, "ab", data.Dropped)
+	require.Equal(t, "1 < 2 and bold", data.Broken)
+	require.Equal(t, []string{"Hello World", "<b> ©"}, data.Text)
+	require.Equal(t, "link", data.Attribute)
+}
+
+func Test_StructWithTagEscapeHTML(t *testing.T) {
+	type testData struct {
+		String  string  `morph:"escapehtml"`
+		Pointer *string `morph:"escapehtml"`
+	}
+
+	pointer := "Tom & Jerry"
+	data := testData{
+		String:  "<a href=\"x\">'quoted'</a>",
+		Pointer: &pointer,
+	}
+
+	transformer := New()
+	err := transformer.Struct(&data)
+
+	require.Nil(t, err)
+	require.Equal(t, "&lt;a href=&#34;x&#34;&gt;&#39;quoted&#39;&lt;/a&gt;", data.String)
+	require.Equal(t, "Tom &amp; Jerry", *data.Pointer)
+}
+
+func Test_StructWithTagSanitizeHTML(t *testing.T) {
+	type testData struct {
+		Attributes string `morph:"sanitizehtml=comments"`
+		Elements   string `morph:"sanitizehtml=comments"`
+		Links      string `morph:"sanitizehtml=comments"`
+		Unbalanced string `morph:"sanitizehtml=comments"`
+		Text       string `morph:"sanitizehtml=comments"`
+	}
+
+	data := testData{
+		Attributes: "<P CLASS=intro ID='x' onclick=\"steal()\">text</P>",
+		Elements:   "<div><b>bold</b><script>alert(1)</script><img src=x onerror=alert(1)><br></div>",
+		Links:      "<a href=\"JaVa\tScript:alert(1)\">x</a><a href=\"/about?a=1&amp;b=2\" target=_blank>y</a><a href=https://example.com>z</a>",
+		Unbalanced: "<b><i>text</b> more</i><p>open",
+		Text:       "1 < 2 &amp; 3 > 2<!-- <b>comment</b> -->",
+	}
+
+	transformer := New()
+	err := transformer.RegisterHTMLPolicy("comments", HTMLPolicy{
+		Elements: map[string][]string{
+			"a":  {"href"},
+			"b":  nil,
+			"i":  nil,
+			"br": nil,
+			"P":  {"Class"},
+		},
+		Attributes: []string{"id"},
+	})
+	require.Nil(t, err)
+
+	err = transformer.Struct(&data)
+
+	require.Nil(t, err)
+	require.Equal(t, "<p class=\"intro\" id=\"x\">text</p>", data.Attributes)
+	require.Equal(t, "<b>bold</b><br>", data.Elements)
+	require.Equal(t, "<a>x</a><a href=\"/about?a=1&amp;b=2\">y</a><a href=\"https://example.com\">z</a>", data.Links)
+	require.Equal(t, "<b><i>text</i></b> more<p>open</p>", data.Unbalanced)
+	require.Equal(t, "1 &lt; 2 &amp; 3 &gt; 2", data.Text)
+}
+
+func Test_StructWithTagSanitizeHTMLSchemes(t *testing.T) {
+	type testData struct {
+		Links []string `morph:"dive,sanitizehtml=links"`
+	}
+
+	data := testData{
+		Links: []string{"<a href=\"tel:+359888\">call</a>", "<a href=\"mailto:a@b.c\">mail</a>"},
+	}
+
+	transformer := New()
+	err := transformer.RegisterHTMLPolicy("links", HTMLPolicy{
+		Elements:   map[string][]string{"a": {"href"}},
+		URLSchemes: []string{"tel"},
+	})
+	require.Nil(t, err)
+
+	err = transformer.Struct(&data)
+
+	require.Nil(t, err)
+	require.Equal(t, []string{"<a href=\"tel:+359888\">call</a>", "<a>mail</a>"}, data.Links)
+}
+
+func Test_StructWithTagSanitizeHTMLUnknownPolicy(t *testing.T) {
+	type testData struct {
+		String string `morph:"sanitizehtml=baba"`
+	}
+
+	type missingData struct {
+		String string `morph:"sanitizehtml"`
+	}
+
+	transformer := New()
+
+	err := transformer.Struct(&testData{})
+	require.Error(t, err)
+	require.Equal(t, "unknown policy: 'baba' for tag: 'sanitizehtml'", err.Error())
+
+	err = transformer.Struct(&missingData{})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "missing parameters")
+}
+
+func Test_RegisterHTMLPolicyInvalid(t *testing.T) {
+	transformer := New()
+
+	err := transformer.RegisterHTMLPolicy(" ", HTMLPolicy{})
+	require.Error(t, err)
+	require.Equal(t, ErrInvalidPolicyName, err.Error())
+
+	err = transformer.RegisterHTMLPolicy("scripts", HTMLPolicy{Elements: map[string][]string{"script": nil}})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "script")
+}
+
+//endregion html
+
+//region email
+
+func Test_StructWithTagEmail(t *testing.T) {
+	type testData struct {
+		Address string   `morph:"email"`
+		IDN     string   `morph:"email"`
+		Empty   string   `morph:"email"`
+		Pointer *string  `morph:"email"`
+		Dive    []string `morph:"dive,email"`
+	}
+
+	pointer := "Info@Пример.БГ"
+	data := testData{
+		Address: "  John.Doe@Example.COM \t",
+		IDN:     "John@Bücher.Example",
+		Pointer: &pointer,
+		Dive:    []string{"a!#$%&'*+-/=?^_`{|}~@example.com", "user@sub.EXAMPLE.co.uk"},
+	}
+
+	transformer := New()
+	err := transformer.Struct(&data)
+
+	require.Nil(t, err)
+	require.Equal(t, "John.Doe@example.com", data.Address)
+	require.Equal(t, "John@xn--bcher-kva.example", data.IDN)
+	require.Equal(t, "", data.Empty)
+	require.Equal(t, "Info@xn--e1afmkfd.xn--90ae", *data.Pointer)
+	require.Equal(t, []string{"a!#$%&'*+-/=?^_`{|}~@example.com", "user@sub.example.co.uk"}, data.Dive)
+}
+
+func Test_StructWithTagEmailOptions(t *testing.T) {
+	type testData struct {
+		Lower     string `morph:"email=lower"`
+		Tag       string `morph:"email=striptag"`
+		Dots      string `morph:"email=stripdots"`
+		OtherDots string `morph:"email=stripdots"`
+		All       string `morph:"email=lower striptag stripdots"`
+	}
+
+	data := testData{
+		Lower:     "John@Example.com",
+		Tag:       "john+news+daily@example.com",
+		Dots:      "j.o.h.n@gmail.com",
+		OtherDots: "j.o.h.n@example.com",
+		All:       "J.Doe+Spam@GoogleMail.com",
+	}
+
+	transformer := New()
+	err := transformer.Struct(&data)
+
+	require.Nil(t, err)
+	require.Equal(t, "john@example.com", data.Lower)
+	require.Equal(t, "john@example.com", data.Tag)
+	require.Equal(t, "john@gmail.com", data.Dots)
+	require.Equal(t, "j.o.h.n@example.com", data.OtherDots)
+	require.Equal(t, "jdoe@googlemail.com", data.All)
+}
+
+func Test_StructWithTagEmailInvalid(t *testing.T) {
+	type testData struct {
+		Email string `morph:"email"`
+	}
+
+	transformer := New()
+	for _, email := range []string{
+		"john",
+		"@example.com",
+		"john@",
+		"john@localhost",
+		"john@example.123",
+		".john@example.com",
+		"john.@example.com",
+		"jo..hn@example.com",
+		"jo hn@example.com",
+		"\"john\"@example.com",
+		"john@-example.com",
+		"john@exa_mple.com",
+		"john@example..com",
+		"john@[127.0.0.1]",
+		strings.Repeat("a", 65) + "@example.com",
+		"john@" + strings.Repeat("a", 64) + ".com",
+	} {
+		data := testData{Email: email}
+		err := transformer.Struct(&data)
+
+		require.Error(t, err, email)
+		require.Contains(t, err.Error(), "invalid value", email)
+		require.Equal(t, email, data.Email)
+	}
+}
+
+func Test_StructWithTagEmailInvalidParameters(t *testing.T) {
+	type testData struct {
+		Email string `morph:"email=baba"`
+	}
+
+	type intData struct {
+		Email int `morph:"email"`
+	}
+
+	transformer := New()
+
+	err := transformer.Struct(&testData{})
+	require.Error(t, err)
+	require.Equal(t, "invalid parameters 'baba' for tag: 'email'", err.Error())
+
+	err = transformer.Struct(&intData{})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "unexpected value")
+}
+
+//endregion email
+
+//region phone
+
+func Test_StructWithTagPhone(t *testing.T) {
+	type testData struct {
+		US            string   `morph:"phone=US"`
+		USPrefix      string   `morph:"phone=us"`
+		USDialed      string   `morph:"phone=US"`
+		International string   `morph:"phone=US"`
+		BG            []string `morph:"dive,phone=BG"`
+		GB            string   `morph:"phone=GB"`
+		IT            string   `morph:"phone=IT"`
+		RU            string   `morph:"phone=RU"`
+		NoRegion      string   `morph:"phone"`
+		NoMetadata    []string `morph:"dive,phone"`
+		Empty         string   `morph:"phone=BG"`
+		Pointer       *string  `morph:"phone=BG"`
+	}
+
+	pointer := "+359 (0)2 123 4567"
+	data := testData{
+		US:            "(555) 123-4567",
+		USPrefix:      "1-555-123-4567",
+		USDialed:      "011 44 20 7946 0958",
+		International: "+44 (0)20 7946 0958",
+		BG:            []string{"0888 123 456", "00359 888 123 456", "+359888123456", "02/123 4567"},
+		GB:            "020 7946 0958",
+		IT:            "06 1234 5678",
+		RU:            "8 (912) 345-67-89",
+		NoRegion:      "+1 555.123.4567",
+		NoMetadata:    []string{"+372 5123 4567", "+373 (0)22 123 456", "+389 2 312 3456"},
+		Pointer:       &pointer,
+	}
+
+	transformer := New()
+	err := transformer.Struct(&data)
+
+	require.Nil(t, err)
+	require.Equal(t, "+15551234567", data.US)
+	require.Equal(t, "+15551234567", data.USPrefix)
+	require.Equal(t, "+442079460958", data.USDialed)
+	require.Equal(t, "+442079460958", data.International)
+	require.Equal(t, []string{"+359888123456", "+359888123456", "+359888123456", "+35921234567"}, data.BG)
+	require.Equal(t, "+442079460958", data.GB)
+	require.Equal(t, "+390612345678", data.IT)
+	require.Equal(t, "+79123456789", data.RU)
+	require.Equal(t, "+15551234567", data.NoRegion)
+	require.Equal(t, []string{"+37251234567", "+37322123456", "+38923123456"}, data.NoMetadata)
+	require.Equal(t, "", data.Empty)
+	require.Equal(t, "+35921234567", *data.Pointer)
+}
+
+func Test_StructWithTagPhoneInvalid(t *testing.T) {
+	type testData struct {
+		Phone string `morph:"phone=BG"`
+	}
+
+	type noRegionData struct {
+		Phone string `morph:"phone"`
+	}
+
+	transformer := New()
+	for _, phone := range []string{"call me", "0888 123 456 ext. 5", "123", "+359 888 123 456 789", "+0 123 456 789",
+		"+372 5123 4567 8901 2345", "+372 51", "+"} {
+		data := testData{Phone: phone}
+		err := transformer.Struct(&data)
+
+		require.Error(t, err, phone)
+		require.Equal(t, "invalid value: '"+phone+"' for tag: 'phone'", err.Error())
+		require.Equal(t, phone, data.Phone)
+	}
+
+	data := noRegionData{Phone: "0888 123 456"}
+	err := transformer.Struct(&data)
+	require.Error(t, err)
+}
+
+func Test_StructWithTagPhonePassthrough(t *testing.T) {
+	type testData struct {
+		Valid   string `morph:"phone=BG passthrough"`
+		Invalid string `morph:"phone=BG passthrough"`
+	}
+
+	data := testData{
+		Valid:   "0888 123 456",
+		Invalid: " call me ",
+	}
+
+	transformer := New()
+	err := transformer.Struct(&data)
+
+	require.Nil(t, err)
+	require.Equal(t, "+359888123456", data.Valid)
+	require.Equal(t, " call me ", data.Invalid)
+}
+
+func Test_StructWithTagPhoneInvalidParameters(t *testing.T) {
+	type unknownData struct {
+		Phone string `morph:"phone=XX"`
+	}
+
+	type twoRegionsData struct {
+		Phone string `morph:"phone=BG US"`
+	}
+
+	transformer := New()
+
+	err := transformer.Struct(&unknownData{})
+	require.Error(t, err)
+	require.Equal(t, "invalid parameters 'XX' for tag: 'phone'", err.Error())
+
+	err = transformer.Struct(&twoRegionsData{})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid parameters")
+}
+
+//endregion phone
+
+//region url
+
+func Test_StructWithTagURL(t *testing.T) {
+	type testData struct {
+		Basic    string   `morph:"url"`
+		Dots     string   `morph:"url"`
+		Encoding string   `morph:"url"`
+		IDN      string   `morph:"url"`
+		Port     string   `morph:"url"`
+		IPv6     string   `morph:"url"`
+		Opaque   string   `morph:"url"`
+		NoHost   []string `morph:"dive,url"`
+		Empty    string   `morph:"url"`
+		Dive     []string `morph:"dive,url"`
+	}
+
+	data := testData{
+		Basic:    " HTTP://User@Example.COM:80?b=2&a=1&a=0#Top ",
+		Dots:     "https://example.com/a/./b/../../c/./d/..",
+		Encoding: "http://example.com/%7euser/%2fdocs/%e2%82%AC file?q=%41%2b#%7e",
+		IDN:      "https://Bücher.example./straße",
+		Port:     "https://example.com:8443/",
+		IPv6:     "http://[2001:DB8::1]:80/",
+		Opaque:   "MAILTO:john@example.com",
+		NoHost:   []string{"FILE:///etc/./hosts", "file:/tmp/a%7eb", "mailto:", "urn:"},
+		Dive:     []string{"http://example.com?", "http://example.com/?x&y=&x=1"},
+	}
+
+	transformer := New()
+	err := transformer.Struct(&data)
+
+	require.Nil(t, err)
+	require.Equal(t, "http://User@example.com/?a=1&a=0&b=2#Top", data.Basic)
+	require.Equal(t, "https://example.com/c/", data.Dots)
+	require.Equal(t, "http://example.com/~user/%2Fdocs/%E2%82%AC%20file?q=A%2B#~", data.Encoding)
+	require.Equal(t, "https://xn--bcher-kva.example/stra%C3%9Fe", data.IDN)
+	require.Equal(t, "https://example.com:8443/", data.Port)
+	require.Equal(t, "http://[2001:db8::1]/", data.IPv6)
+	require.Equal(t, "mailto:john@example.com", data.Opaque)
+	require.Equal(t, []string{"file:///etc/hosts", "file:/tmp/a~b", "mailto:", "urn:"}, data.NoHost)
+	require.Equal(t, "", data.Empty)
+	require.Equal(t, []string{"http://example.com/", "http://example.com/?x&x=1&y="}, data.Dive)
+}
+
+func Test_StructWithTagURLOptions(t *testing.T) {
+	type testData struct {
+		Strip    string `morph:"url=strip=utm_*|fbclid"`
+		Fragment string `morph:"url=nofragment"`
+		Both     string `morph:"url=nofragment strip=id"`
+	}
+
+	data := testData{
+		Strip:    "http://example.com/?utm_source=x&fbclid=1&id=1&utm=2&utm_medium=y",
+		Fragment: "http://example.com/page#top",
+		Both:     "http://example.com/page?id=1#top",
+	}
+
+	transformer := New()
+	err := transformer.Struct(&data)
+
+	require.Nil(t, err)
+	require.Equal(t, "http://example.com/?id=1&utm=2", data.Strip)
+	require.Equal(t, "http://example.com/page", data.Fragment)
+	require.Equal(t, "http://example.com/page", data.Both)
+}
+
+func Test_StructWithTagURLType(t *testing.T) {
+	type testData struct {
+		Pointer *url.URL `morph:"url=strip=utm_*"`
+		Value   url.URL  `morph:"url"`
+		Nil     *url.URL `morph:"url"`
+	}
+
+	pointer, err := url.Parse("HTTPS://Example.com:443/a/../b?utm_source=x&b=1")
+	require.Nil(t, err)
+
+	value, err := url.Parse("http://example.com")
+	require.Nil(t, err)
+
+	data := testData{
+		Pointer: pointer,
+		Value:   *value,
+	}
+
+	transformer := New()
+	err = transformer.Struct(&data)
+
+	require.Nil(t, err)
+	require.Equal(t, "https://example.com/b?b=1", data.Pointer.String())
+	require.Equal(t, "example.com", data.Pointer.Host)
+	require.Equal(t, "http://example.com/", data.Value.String())
+	require.Nil(t, data.Nil)
+}
+
+func Test_StructWithTagURLInvalid(t *testing.T) {
+	type testData struct {
+		URL string `morph:"url"`
+	}
+
+	transformer := New()
+	for _, link := range []string{"example.com/page", "/relative/path", "http://", "http://exa mple.com", "http://%zz", "http://-example.com/",
+		"https:///path", "ftp:/file", "ws:"} {
+		data := testData{URL: link}
+		err := transformer.Struct(&data)
+
+		require.Error(t, err, link)
+		require.Contains(t, err.Error(), "invalid value", link)
+		require.Equal(t, link, data.URL)
+	}
+}
+
+func Test_StructWithTagURLInvalidParameters(t *testing.T) {
+	type testData struct {
+		URL string `morph:"url=baba"`
+	}
+
+	type emptyData struct {
+		URL string `morph:"url=strip="`
+	}
+
+	type intData struct {
+		URL int `morph:"url"`
+	}
+
+	transformer := New()
+
+	err := transformer.Struct(&testData{})
+	require.Error(t, err)
+	require.Equal(t, "invalid parameters 'baba' for tag: 'url'", err.Error())
+
+	err = transformer.Struct(&emptyData{})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid parameters")
+
+	err = transformer.Struct(&intData{})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "unexpected value")
+}
+
+//endregion url
+
+//region network
+
+func Test_StructWithTagIP(t *testing.T) {
+	type testData struct {
+		IPv4    string   `morph:"ip"`
+		IPv6    string   `morph:"ip"`
+		Mapped  string   `morph:"ip"`
+		Zone    string   `morph:"ip"`
+		Empty   string   `morph:"ip"`
+		Dive    []string `morph:"dive,ip"`
+		IP      net.IP   `morph:"ip"`
+		Pointer *net.IP  `morph:"ip"`
+		Nil     net.IP   `morph:"ip"`
+	}
+
+	pointer := net.ParseIP("2001:db8::1")
+	data := testData{
+		IPv4:    " 192.0.2.1 ",
+		IPv6:    "2001:0DB8:0000:0000:0000:0000:0000:0001",
+		Mapped:  "::ffff:192.0.2.1",
+		Zone:    "FE80::1%eth0",
+		Dive:    []string{"::1", "2001:db8:0:0:1:0:0:1"},
+		IP:      net.ParseIP("192.0.2.1"),
+		Pointer: &pointer,
+	}
+
+	transformer := New()
+	err := transformer.Struct(&data)
+
+	require.Nil(t, err)
+	require.Equal(t, "192.0.2.1", data.IPv4)
+	require.Equal(t, "2001:db8::1", data.IPv6)
+	require.Equal(t, "192.0.2.1", data.Mapped)
+	require.Equal(t, "fe80::1%eth0", data.Zone)
+	require.Equal(t, "", data.Empty)
+	require.Equal(t, []string{"::1", "2001:db8::1:0:0:1"}, data.Dive)
+	require.Equal(t, net.IP{192, 0, 2, 1}, data.IP)
+	require.Equal(t, net.ParseIP("2001:db8::1"), *data.Pointer)
+	require.Nil(t, data.Nil)
+}
+
+func Test_StructWithTagIPText(t *testing.T) {
+	type testData struct {
+		Text   []byte          `morph:"ip"`
+		Mapped []byte          `morph:"ip"`
+		Raw    json.RawMessage `morph:"ip"`
+		Nil    []byte          `morph:"ip"`
+	}
+
+	data := testData{
+		Text:   []byte(" 192.0.2.1 "),
+		Mapped: []byte("::FFFF:192.0.2.1"),
+		Raw:    json.RawMessage("2001:DB8::1"),
+	}
+
+	transformer := New()
+	err := transformer.Struct(&data)
+
+	require.Nil(t, err)
+	require.Equal(t, []byte("192.0.2.1"), data.Text)
+	require.Equal(t, []byte("192.0.2.1"), data.Mapped)
+	require.Equal(t, json.RawMessage("2001:db8::1"), data.Raw)
+	require.Nil(t, data.Nil)
+}
+
+func Test_StructWithTagCIDR(t *testing.T) {
+	type testData struct {
+		IPv4    string     `morph:"cidr"`
+		IPv6    string     `morph:"cidr"`
+		Mapped  string     `morph:"cidr"`
+		Network net.IPNet  `morph:"cidr"`
+		Pointer *net.IPNet `morph:"cidr"`
+		Nil     *net.IPNet `morph:"cidr"`
+	}
+
+	data := testData{
+		IPv4:    "192.0.2.15/24",
+		IPv6:    "2001:DB8::1/32",
+		Mapped:  "::ffff:192.0.2.15/120",
+		Network: net.IPNet{IP: net.ParseIP("10.1.2.3"), Mask: net.CIDRMask(8, 32)},
+		Pointer: &net.IPNet{IP: net.ParseIP("2001:db8::ff"), Mask: net.CIDRMask(64, 128)},
+	}
+
+	transformer := New()
+	err := transformer.Struct(&data)
+
+	require.Nil(t, err)
+	require.Equal(t, "192.0.2.0/24", data.IPv4)
+	require.Equal(t, "2001:db8::/32", data.IPv6)
+	require.Equal(t, "192.0.2.0/24", data.Mapped)
+	require.Equal(t, "10.0.0.0/8", data.Network.String())
+	require.Equal(t, net.IP{10, 0, 0, 0}, data.Network.IP)
+	require.Equal(t, "2001:db8::/64", data.Pointer.String())
+	require.Nil(t, data.Nil)
+}
+
+func Test_StructWithTagMAC(t *testing.T) {
+	type testData struct {
+		Colons  string           `morph:"mac"`
+		Hyphens string           `morph:"mac"`
+		Cisco   string           `morph:"mac"`
+		Plain   string           `morph:"mac"`
+		Short   string           `morph:"mac"`
+		EUI64   string           `morph:"mac"`
+		Address net.HardwareAddr `morph:"mac"`
+	}
+
+	data := testData{
+		Colons:  "01:23:45:67:89:AB",
+		Hyphens: "01-23-45-67-89-ab",
+		Cisco:   "0123.4567.89AB",
+		Plain:   "0123456789ab",
+		Short:   "1:23:45:6:89:ab",
+		EUI64:   "01-23-45-67-89-ab-cd-ef",
+		Address: net.HardwareAddr{1, 2, 3, 4, 5, 6},
+	}
+
+	transformer := New()
+	err := transformer.Struct(&data)
+
+	require.Nil(t, err)
+	require.Equal(t, "01:23:45:67:89:ab", data.Colons)
+	require.Equal(t, "01:23:45:67:89:ab", data.Hyphens)
+	require.Equal(t, "01:23:45:67:89:ab", data.Cisco)
+	require.Equal(t, "01:23:45:67:89:ab", data.Plain)
+	require.Equal(t, "01:23:45:06:89:ab", data.Short)
+	require.Equal(t, "01:23:45:67:89:ab:cd:ef", data.EUI64)
+	require.Equal(t, net.HardwareAddr{1, 2, 3, 4, 5, 6}, data.Address)
+}
+
+func Test_StructWithTagMACText(t *testing.T) {
+	type testData struct {
+		Hyphens []byte `morph:"mac"`
+		Cisco   []byte `morph:"mac"`
+		Nil     []byte `morph:"mac"`
+	}
+
+	data := testData{
+		Hyphens: []byte("AA-BB-CC-DD-EE-FF"),
+		Cisco:   []byte("0123.4567.89AB"),
+	}
+
+	transformer := New()
+	err := transformer.Struct(&data)
+
+	require.Nil(t, err)
+	require.Equal(t, []byte("aa:bb:cc:dd:ee:ff"), data.Hyphens)
+	require.Equal(t, []byte("01:23:45:67:89:ab"), data.Cisco)
+	require.Nil(t, data.Nil)
+}
+
+func Test_StructWithTagMACInvalidGroups(t *testing.T) {
+	type testData struct {
+		Value string `morph:"mac"`
+	}
+
+	transformer := New()
+	for _, value := range []string{"01::23:45:67:89:ab", ":01:23:45:67:89:ab", "01:23:45:67:89:ab:",
+		"01:23-45:67:89:ab", "0123.4567 89ab"} {
+		data := testData{Value: value}
+		err := transformer.Struct(&data)
+
+		require.Error(t, err, value)
+		require.Equal(t, fmt.Sprintf("invalid value: '%s' for tag: 'mac'", value), err.Error())
+	}
+}
+
+func Test_StructWithTagHostname(t *testing.T) {
+	type testData struct {
+		Hostname string   `morph:"hostname"`
+		Hosts    []string `morph:"dive,hostname"`
+	}
+
+	data := testData{
+		Hostname: " Bücher.Example. ",
+		Hosts:    []string{"LOCALHOST", "xn--bcher-kva.example", "mail.example.com"},
+	}
+
+	transformer := New()
+	err := transformer.Struct(&data)
+
+	require.Nil(t, err)
+	require.Equal(t, "xn--bcher-kva.example", data.Hostname)
+	require.Equal(t, []string{"localhost", "xn--bcher-kva.example", "mail.example.com"}, data.Hosts)
+}
+
+func Test_StructWithTagNetworkInvalid(t *testing.T) {
+	type ipData struct {
+		Value string `morph:"ip"`
+	}
+
+	type cidrData struct {
+		Value string `morph:"cidr"`
+	}
+
+	type macData struct {
+		Value string `morph:"mac"`
+	}
+
+	type hostnameData struct {
+		Value string `morph:"hostname"`
+	}
+
+	transformer := New()
+	invalid := map[interface{}][]string{
+		&ipData{}:       {"256.0.0.1", "192.0.2.1/24", "01.2.3.4", "1.2.3.4%eth0", "host"},
+		&cidrData{}:     {"192.0.2.1", "192.0.2.0/33", "192.0.2.0/"},
+		&macData{}:      {"01:23:45:67:89", "01:23:45:67:89:zz", "0123.4567.89ab.cd", "012:345:678:9ab"},
+		&hostnameData{}: {"exa_mple.com", "-example.com", "example..com", strings.Repeat("a", 64) + ".com"},
+	}
+
+	for data, values := range invalid {
+		for _, value := range values {
+			field := reflect.ValueOf(data).Elem().Field(0)
+			field.SetString(value)
+
+			err := transformer.Struct(data)
+			require.Error(t, err, value)
+			require.Contains(t, err.Error(), "invalid value", value)
+			require.Equal(t, value, field.String())
+		}
+	}
+}
+
+func Test_StructWithTagIPUnexpectedValue(t *testing.T) {
+	type testData struct {
+		Network net.IPNet `morph:"ip"`
+	}
+
+	transformer := New()
+	err := transformer.Struct(&testData{})
+
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "unexpected value")
+}
+
+//endregion network
+
+//region masking
+
+func Test_StructWithTagMask(t *testing.T) {
+	type testData struct {
+		All      string   `morph:"mask"`
+		First    string   `morph:"mask=2"`
+		Both     string   `morph:"mask=2 2"`
+		Char     string   `morph:"mask=0 4 #"`
+		Short    string   `morph:"mask=2 2"`
+		Accented string   `morph:"mask=1 1"`
+		Dive     []string `morph:"dive,mask=1"`
+	}
+
+	data := testData{
+		All:      "secret",
+		First:    "secret",
+		Both:     "secret",
+		Char:     "+359888123456",
+		Short:    "abc",
+		Accented: "Jose\u0301",
+		Dive:     []string{"ab", ""},
+	}
+
+	transformer := New()
+	err := transformer.Struct(&data)
+
+	require.Nil(t, err)
+	require.Equal(t, "******", data.All)
+	require.Equal(t, "se****", data.First)
+	require.Equal(t, "se**et", data.Both)
+	require.Equal(t, "#########3456", data.Char)
+	require.Equal(t, "***", data.Short)
+	require.Equal(t, "J**e\u0301", data.Accented)
+	require.Equal(t, []string{"a*", ""}, data.Dive)
+}
+
+func Test_StructWithTagMaskEmail(t *testing.T) {
+	type testData struct {
+		Emails []string `morph:"dive,maskemail"`
+	}
+
+	data := testData{
+		Emails: []string{"john@example.com", "j@example.com", "invalid", "@example.com", ""},
+	}
+
+	transformer := New()
+	err := transformer.Struct(&data)
+
+	require.Nil(t, err)
+	require.Equal(t, []string{"j***@example.com", "j***@example.com", "*******", "************", ""}, data.Emails)
+}
+
+func Test_StructWithTagMaskCard(t *testing.T) {
+	type testData struct {
+		Spaces  string  `morph:"maskcard"`
+		Hyphens string  `morph:"maskcard"`
+		Plain   string  `morph:"maskcard"`
+		Empty   string  `morph:"maskcard"`
+		Pointer *string `morph:"maskcard"`
+	}
+
+	pointer := "378282246310005"
+	data := testData{
+		Spaces:  "4111 1111 1111 1111",
+		Hyphens: "5555-5555-5555-4444",
+		Plain:   "4012888888881881",
+		Pointer: &pointer,
+	}
+
+	transformer := New()
+	err := transformer.Struct(&data)
+
+	require.Nil(t, err)
+	require.Equal(t, "**** **** **** 1111", data.Spaces)
+	require.Equal(t, "****-****-****-4444", data.Hyphens)
+	require.Equal(t, "************1881", data.Plain)
+	require.Equal(t, "", data.Empty)
+	require.Equal(t, "***********0005", *data.Pointer)
+}
+
+func Test_StructWithTagMaskCardInvalid(t *testing.T) {
+	type testData struct {
+		Card string `morph:"maskcard"`
+	}
+
+	transformer := New()
+	for _, card := range []string{"4111 1111 1111 1112", "4111", "4111/1111/1111/1111", "41111111111111111111"} {
+		data := testData{Card: card}
+		err := transformer.Struct(&data)
+
+		require.Error(t, err, card)
+		require.Equal(t, "invalid value for tag: 'maskcard'", err.Error())
+		require.Equal(t, card, data.Card)
+	}
+}
+
+func Test_StructWithTagRedact(t *testing.T) {
+	type testData struct {
+		Default string   `morph:"redact"`
+		Text    string   `morph:"redact=hidden"`
+		Empty   string   `morph:"redact"`
+		Dive    []string `morph:"dive,redact=x"`
+	}
+
+	data := testData{
+		Default: "secret",
+		Text:    "secret",
+		Dive:    []string{"a", "b"},
+	}
+
+	transformer := New()
+	err := transformer.Struct(&data)
+
+	require.Nil(t, err)
+	require.Equal(t, "[REDACTED]", data.Default)
+	require.Equal(t, "hidden", data.Text)
+	require.Equal(t, "", data.Empty)
+	require.Equal(t, []string{"x", "x"}, data.Dive)
+}
+
+func Test_StructWithTagZero(t *testing.T) {
+	type innerData struct {
+		String string `morph:"upper"`
+	}
+
+	type testData struct {
+		String  string               `morph:"zero"`
+		Int     int                  `morph:"zero"`
+		Struct  innerData            `morph:"zero"`
+		Pointer *innerData           `morph:"zero"`
+		Slice   []string             `morph:"zero"`
+		Map     map[string]innerData `morph:"zero"`
+		Dive    []innerData          `morph:"dive,zero"`
+		Chained string               `morph:"trim,zero,upper"`
+		Time    time.Time            `morph:"zero"`
+		Any     interface{}          `morph:"zero"`
+	}
+
+	data := testData{
+		String:  "value",
+		Int:     5,
+		Struct:  innerData{"value"},
+		Pointer: &innerData{"value"},
+		Slice:   []string{"value"},
+		Map:     map[string]innerData{"key": {"value"}},
+		Dive:    []innerData{{"a"}, {"b"}},
+		Chained: " value ",
+		Time:    time.Now(),
+		Any:     "value",
+	}
+
+	transformer := New()
+	err := transformer.Struct(&data)
+
+	require.Nil(t, err)
+	require.Equal(t, testData{Dive: []innerData{{}, {}}}, data)
+}
+
+func Test_StructWithMaskingTagName(t *testing.T) {
+	type credentials struct {
+		Token string `log:"zero"`
+	}
+
+	type testData struct {
+		Name        string `morph:"trim" log:"mask=1"`
+		Email       string `morph:"trim,lower" log:"maskemail"`
+		Card        string `log:"maskcard"`
+		Credentials credentials
+	}
+
+	data := testData{
+		Name:        " John ",
+		Email:       " John@Example.com ",
+		Card:        "4111 1111 1111 1111",
+		Credentials: credentials{"token"},
+	}
+
+	err := New().Struct(&data)
+	require.Nil(t, err)
+
+	logged := data
+	err = New().WithTag("log").Struct(&logged)
+
+	require.Nil(t, err)
+	require.Equal(t, testData{"John", "john@example.com", "4111 1111 1111 1111", credentials{"token"}}, data)
+	require.Equal(t, testData{"J***", "j***@example.com", "**** **** **** 1111", credentials{}}, logged)
+}
+
+func Test_StructWithTagMaskInvalidParameters(t *testing.T) {
+	type testData struct {
+		String string `morph:"mask=a"`
+	}
+
+	type charData struct {
+		String string `morph:"mask=1 1 ab"`
+	}
+
+	type negativeData struct {
+		String string `morph:"mask=-1"`
+	}
+
+	transformer := New()
+
+	err := transformer.Struct(&testData{})
+	require.Error(t, err)
+	require.Equal(t, "invalid parameters 'a' for tag: 'mask'", err.Error())
+
+	err = transformer.Struct(&charData{})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid parameters")
+
+	err = transformer.Struct(&negativeData{})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid parameters")
+}
+
+//endregion masking
+
+//region hashing
+
+func Test_StructWithTagHash(t *testing.T) {
+	type testData struct {
+		SHA256 string   `morph:"hash=sha256"`
+		SHA512 string   `morph:"hash=sha512 base64"`
+		Empty  string   `morph:"hash=sha256"`
+		Dive   []string `morph:"dive,hash=sha256 base64url"`
+	}
+
+	data := testData{
+		SHA256: "value",
+		SHA512: "value",
+		Dive:   []string{"value"},
+	}
+
+	transformer := New()
+	err := transformer.Struct(&data)
+
+	require.Nil(t, err)
+	require.Equal(t, "cd42404d52ad55ccfa9aca4adc828aa5800ad9d385a0671fbcbf724118320619", data.SHA256)
+	require.Equal(t, "7CyD7ey2AwTRVOvbhb369hqSvRQuccT3sloVuctfPArjAc+zVpzyQORHADE4U0i8KW2NmdCeBrJvCVkal1Jylg==", data.SHA512)
+	require.Equal(t, "", data.Empty)
+	require.Equal(t, []string{"zUJATVKtVcz6mspK3IKKpYAK2dOFoGcfvL9yQRgyBhk"}, data.Dive)
+}
+
+func Test_StructWithTagHMAC(t *testing.T) {
+	type testData struct {
+		Hex     string  `morph:"hmac=users"`
+		SHA512  string  `morph:"hmac=users base64 sha512"`
+		Token   string  `morph:"trim,lower,tokenize=users"`
+		Empty   string  `morph:"tokenize=users"`
+		Pointer *string `morph:"hmac=users"`
+	}
+
+	pointer := "john@example.com"
+	data := testData{
+		Hex:     "john@example.com",
+		SHA512:  "john@example.com",
+		Token:   " John@Example.com ",
+		Pointer: &pointer,
+	}
+
+	transformer := New()
+	err := transformer.RegisterKeyProvider(StaticKeys{"users": []byte("secret-key")})
+	require.Nil(t, err)
+
+	err = transformer.Struct(&data)
+
+	require.Nil(t, err)
+	require.Equal(t, "bfdffd5529835960b788d9985c173660ce31953799c0bce1bf7609b75fbc3658", data.Hex)
+	require.Equal(t, "iMaPAhN9kB+e6+7ggaI5tZLkCnDmNf3Xatp/TPZNwFMCSpEKDPLHnrv8Ffv9RTL4g6GTMiafofteJwtoO2qmNQ==", data.SHA512)
+	require.Equal(t, "v9_9VSmDWWC3iNmYXBc2YA", data.Token)
+	require.Equal(t, "", data.Empty)
+	require.Equal(t, data.Hex, *data.Pointer)
+}
+
+func Test_StructWithTagHMACUnknownKey(t *testing.T) {
+	type testData struct {
+		String string `morph:"hmac=users"`
+	}
+
+	type tokenData struct {
+		String string `morph:"tokenize=orders"`
+	}
+
+	transformer := New()
+
+	err := transformer.Struct(&testData{String: "value"})
+	require.Error(t, err)
+	require.Equal(t, "unknown key: 'users' for tag: 'hmac'", err.Error())
+
+	err = transformer.RegisterKeyProvider(StaticKeys{"users": []byte("secret-key"), "orders": nil})
+	require.Nil(t, err)
+
+	err = transformer.Struct(&tokenData{String: "value"})
+	require.Error(t, err)
+	require.Equal(t, "unknown key: 'orders' for tag: 'tokenize'", err.Error())
+}
+
+func Test_StructWithTagHashInvalidParameters(t *testing.T) {
+	type unknownData struct {
+		String string `morph:"hash=md5"`
+	}
+
+	type missingData struct {
+		String string `morph:"hmac"`
+	}
+
+	type twiceData struct {
+		String string `morph:"hmac=users sha256 sha512"`
+	}
+
+	type tokenData struct {
+		String string `morph:"tokenize=users hex"`
+	}
+
+	transformer := New()
+	err := transformer.RegisterKeyProvider(StaticKeys{"users": []byte("secret-key")})
+	require.Nil(t, err)
+
+	err = transformer.Struct(&unknownData{})
+	require.Error(t, err)
+	require.Equal(t, "invalid parameters 'md5' for tag: 'hash'", err.Error())
+
+	err = transformer.Struct(&missingData{})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "missing parameters")
+
+	err = transformer.Struct(&twiceData{})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid parameters")
+
+	err = transformer.Struct(&tokenData{})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid parameters")
+}
+
+func Test_RegisterKeyProviderNil(t *testing.T) {
+	err := New().RegisterKeyProvider(nil)
+
+	require.Error(t, err)
+	require.Equal(t, ErrInvalidKeyProvider, err.Error())
+}
+
+//endregion hashing
+
+//region encryption
+
+type rotatingKeys struct {
+	current  string
+	versions map[string][]byte
+}
+
+func (k *rotatingKeys) Key(name string) (string, []byte, bool) {
+	if name != "pii" {
+		return "", nil, false
+	}
+
+	return k.current, k.versions[k.current], true
+}
+
+func (k *rotatingKeys) KeyByID(name, id string) ([]byte, bool) {
+	key, ok := k.versions[id]
+	return key, ok && name == "pii"
+}
+
+func Test_StructWithTagEncryptDecrypt(t *testing.T) {
+	type testData struct {
+		Email   string   `store:"trim,encrypt=pii" load:"decrypt=pii"`
+		Empty   string   `store:"encrypt=pii" load:"decrypt=pii"`
+		Pointer *string  `store:"encrypt=pii" load:"decrypt=pii"`
+		Notes   []string `store:"dive,encrypt=pii" load:"dive,decrypt=pii"`
+	}
+
+	keys := StaticKeys{"pii": []byte("0123456789abcdef0123456789abcdef")}
+	store := New().WithTag("store")
+	require.Nil(t, store.RegisterKeyProvider(keys))
+
+	load := New().WithTag("load")
+	require.Nil(t, load.RegisterKeyProvider(keys))
+
+	pointer := "Ще бъде криптирано"
+	data := testData{
+		Email:   " john@example.com ",
+		Pointer: &pointer,
+		Notes:   []string{"first", "first"},
+	}
+
+	err := store.Struct(&data)
+
+	require.Nil(t, err)
+	require.NotEqual(t, "john@example.com", data.Email)
+	require.NotContains(t, data.Email, "john")
+	require.Equal(t, "", data.Empty)
+	require.NotEqual(t, data.Notes[0], data.Notes[1])
+
+	err = load.Struct(&data)
+
+	require.Nil(t, err)
+	require.Equal(t, "john@example.com", data.Email)
+	require.Equal(t, "", data.Empty)
+	require.Equal(t, "Ще бъде криптирано", *data.Pointer)
+	require.Equal(t, []string{"first", "first"}, data.Notes)
+}
+
+func Test_StructWithTagDecryptRotatedKey(t *testing.T) {
+	type testData struct {
+		Old string `store:"encrypt=pii" load:"decrypt=pii"`
+		New string `store:"encrypt=pii" load:"decrypt=pii"`
+	}
+
+	keys := &rotatingKeys{"v1", map[string][]byte{"v1": []byte("0123456789abcdef")}}
+	store := New().WithTag("store")
+	require.Nil(t, store.RegisterKeyProvider(keys))
+
+	load := New().WithTag("load")
+	require.Nil(t, load.RegisterKeyProvider(keys))
+
+	old := testData{Old: "old secret"}
+	require.Nil(t, store.Struct(&old))
+
+	keys.current, keys.versions["v2"] = "v2", []byte("fedcba9876543210fedcba9876543210")
+	current := testData{New: "new secret"}
+	require.Nil(t, store.Struct(&current))
+
+	rotated := testData{Old: old.Old, New: current.New}
+	err := load.Struct(&rotated)
+
+	require.Nil(t, err)
+	require.Equal(t, testData{"old secret", "new secret"}, rotated)
+
+	delete(keys.versions, "v1")
+	rotated = testData{Old: old.Old}
+	err = load.Struct(&rotated)
+
+	require.Error(t, err)
+	require.Equal(t, "unknown key: 'pii/v1' for tag: 'decrypt'", err.Error())
+}
+
+func Test_StructWithTagEncryptTwice(t *testing.T) {
+	type testData struct {
+		Value string `store:"encrypt=pii" load:"decrypt=pii"`
+	}
+
+	keys := StaticKeys{"pii": []byte("0123456789abcdef")}
+	store := New().WithTag("store")
+	require.Nil(t, store.RegisterKeyProvider(keys))
+
+	load := New().WithTag("load")
+	require.Nil(t, load.RegisterKeyProvider(keys))
+
+	data := testData{Value: "secret"}
+	require.Nil(t, store.Struct(&data))
+
+	encrypted := data.Value
+	require.Nil(t, store.Struct(&data))
+	require.Equal(t, encrypted, data.Value)
+
+	require.Nil(t, load.Struct(&data))
+	require.Equal(t, "secret", data.Value)
+}
+
+func Test_StructWithTagDecryptChangedKeyID(t *testing.T) {
+	type testData struct {
+		Value string `store:"encrypt=pii" load:"decrypt=pii"`
+	}
+
+	key := []byte("0123456789abcdef")
+	keys := &rotatingKeys{"v1", map[string][]byte{"v1": key, "v2": key}}
+	store := New().WithTag("store")
+	require.Nil(t, store.RegisterKeyProvider(keys))
+
+	load := New().WithTag("load")
+	require.Nil(t, load.RegisterKeyProvider(keys))
+
+	data := testData{Value: "secret"}
+	require.Nil(t, store.Struct(&data))
+
+	payload, err := base64.RawURLEncoding.DecodeString(data.Value)
+	require.Nil(t, err)
+
+	payload[3] = '2'
+	data.Value = base64.RawURLEncoding.EncodeToString(payload)
+	err = load.Struct(&data)
+
+	require.Error(t, err)
+	require.Equal(t, "invalid value for tag: 'decrypt'", err.Error())
+}
+
+func Test_StructWithTagDecryptInvalid(t *testing.T) {
+	type testData struct {
+		Value string `store:"encrypt=pii" load:"decrypt=pii"`
+	}
+
+	store := New().WithTag("store")
+	require.Nil(t, store.RegisterKeyProvider(StaticKeys{"pii": []byte("0123456789abcdef")}))
+
+	load := New().WithTag("load")
+	require.Nil(t, load.RegisterKeyProvider(StaticKeys{"pii": []byte("fedcba9876543210")}))
+
+	data := testData{Value: "secret"}
+	require.Nil(t, store.Struct(&data))
+
+	tampered := []byte(data.Value)
+	tampered[len(tampered)-1] ^= 1
+
+	for _, value := range []string{data.Value, string(tampered), "not encrypted", "AA"} {
+		data := testData{Value: value}
+		err := load.Struct(&data)
+
+		require.Error(t, err, value)
+		require.Equal(t, "invalid value for tag: 'decrypt'", err.Error())
+		require.Equal(t, value, data.Value)
+	}
+}
+
+func Test_StructWithTagEncryptInvalidKey(t *testing.T) {
+	type testData struct {
+		Value string `morph:"encrypt=pii"`
+	}
+
+	type missingData struct {
+		Value string `morph:"decrypt"`
+	}
+
+	transformer := New()
+
+	err := transformer.Struct(&testData{})
+	require.Error(t, err)
+	require.Equal(t, "unknown key: 'pii' for tag: 'encrypt'", err.Error())
+
+	require.Nil(t, transformer.RegisterKeyProvider(StaticKeys{"pii": []byte("short")}))
+
+	err = transformer.Struct(&testData{})
+	require.Error(t, err)
+	require.Equal(t, "invalid key: 'pii' for tag: 'encrypt'", err.Error())
+
+	err = transformer.Struct(&missingData{})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "missing parameters")
+}
+
+//endregion encryption
+
+//region encoding
+
+func Test_StructWithTagBase64(t *testing.T) {
+	type testData struct {
+		Std     string  `morph:"b64enc"`
+		URL     string  `morph:"b64enc=url"`
+		RawURL  string  `morph:"b64enc=rawurl"`
+		RawStd  []byte  `morph:"b64enc=rawstd"`
+		Decode  string  `morph:"b64dec"`
+		Bytes   []byte  `morph:"b64dec=rawurl"`
+		Pointer *[]byte `morph:"b64enc"`
+		Nil     []byte  `morph:"b64enc"`
+	}
+
+	pointer := []byte{0xff, 0xfe}
+	data := testData{
+		Std:     "ab?",
+		URL:     "ab?",
+		RawURL:  "ab?",
+		RawStd:  []byte("ab?a"),
+		Decode:  "YWI/",
+		Bytes:   []byte("__4"),
+		Pointer: &pointer,
+	}
+
+	transformer := New()
+	err := transformer.Struct(&data)
+
+	require.Nil(t, err)
+	require.Equal(t, "YWI/", data.Std)
+	require.Equal(t, "YWI_", data.URL)
+	require.Equal(t, "YWI_", data.RawURL)
+	require.Equal(t, []byte("YWI/YQ"), data.RawStd)
+	require.Equal(t, "ab?", data.Decode)
+	require.Equal(t, []byte{0xff, 0xfe}, data.Bytes)
+	require.Equal(t, []byte("//4="), *data.Pointer)
+	require.Nil(t, data.Nil)
+}
+
+func Test_StructWithTagHex(t *testing.T) {
+	type testData struct {
+		Encode string `morph:"hexenc"`
+		Bytes  []byte `morph:"hexenc"`
+		Decode string `morph:"hexdec"`
+		Upper  []byte `morph:"hexdec"`
+	}
+
+	data := testData{
+		Encode: "ab",
+		Bytes:  []byte{0xde, 0xad},
+		Decode: "6162",
+		Upper:  []byte("DEAD"),
+	}
+
+	transformer := New()
+	err := transformer.Struct(&data)
+
+	require.Nil(t, err)
+	require.Equal(t, "6162", data.Encode)
+	require.Equal(t, []byte("dead"), data.Bytes)
+	require.Equal(t, "ab", data.Decode)
+	require.Equal(t, []byte{0xde, 0xad}, data.Upper)
+}
+
+func Test_StructWithTagURLEncoding(t *testing.T) {
+	type testData struct {
+		Query      string   `morph:"urlenc"`
+		Path       string   `morph:"urlenc=path"`
+		Decode     string   `morph:"urldec"`
+		PathDecode []byte   `morph:"urldec=path"`
+		Dive       []string `morph:"dive,urlenc=query"`
+	}
+
+	data := testData{
+		Query:      "a b&c=д",
+		Path:       "a b&c/d",
+		Decode:     "a+b%26c",
+		PathDecode: []byte("a+b%20c"),
+		Dive:       []string{"?", "#"},
+	}
+
+	transformer := New()
+	err := transformer.Struct(&data)
+
+	require.Nil(t, err)
+	require.Equal(t, "a+b%26c%3D%D0%B4", data.Query)
+	require.Equal(t, "a%20b&c%2Fd", data.Path)
+	require.Equal(t, "a b&c", data.Decode)
+	require.Equal(t, []byte("a+b c"), data.PathDecode)
+	require.Equal(t, []string{"%3F", "%23"}, data.Dive)
+}
+
+func Test_StructWithTagQPDecode(t *testing.T) {
+	type testData struct {
+		String string `morph:"qpdec"`
+		Bytes  []byte `morph:"qpdec"`
+	}
+
+	data := testData{
+		String: "caf=C3=A9 =\r\nau lait =ZZ",
+		Bytes:  []byte("a=3Db"),
+	}
+
+	transformer := New()
+	err := transformer.Struct(&data)
+
+	require.Nil(t, err)
+	require.Equal(t, "café au lait =ZZ", data.String)
+	require.Equal(t, []byte("a=b"), data.Bytes)
+}
+
+func Test_StructWithTagDecodeInvalid(t *testing.T) {
+	type testData struct {
+		Base64 string `morph:"b64dec"`
+		Hex    string `morph:"hexdec"`
+		URL    string `morph:"urldec"`
+	}
+
+	transformer := New()
+	for i, value := range []string{"YWI", "616", "%zz"} {
+		data := testData{}
+		field := reflect.ValueOf(&data).Elem().Field(i)
+		field.SetString(value)
+
+		err := transformer.Struct(&data)
+		require.Error(t, err, value)
+		require.Contains(t, err.Error(), "invalid value: '"+value+"'")
+		require.Equal(t, value, field.String())
+	}
+}
+
+func Test_StructWithTagEncodingInvalidParameters(t *testing.T) {
+	type base64Data struct {
+		String string `morph:"b64enc=baba"`
+	}
+
+	type urlData struct {
+		String string `morph:"urldec=baba"`
+	}
+
+	type intData struct {
+		Ints []int `morph:"hexenc"`
+	}
+
+	transformer := New()
+
+	err := transformer.Struct(&base64Data{})
+	require.Error(t, err)
+	require.Equal(t, "invalid parameters 'baba' for tag: 'b64enc'", err.Error())
+
+	err = transformer.Struct(&urlData{})
+	require.Error(t, err)
+	require.Equal(t, "invalid parameters 'baba' for tag: 'urldec'", err.Error())
+
+	err = transformer.Struct(&intData{Ints: []int{1}})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "unexpected value")
+}
+
+//endregion encoding
+
+//region bytes
+
+func Test_StructWithByteSlices(t *testing.T) {
+	type testData struct {
+		Trim     []byte          `morph:"trim,lower"`
+		Truncate []byte          `morph:"truncate=3"`
+		Raw      json.RawMessage `morph:"trim"`
+		Pointer  *[]byte         `morph:"upper"`
+		Nil      []byte          `morph:"trim"`
+		Default  []byte          `morph:"default=value"`
+		Dive     [][]byte        `morph:"dive,squish"`
+		Slug     []byte          `morph:"slug"`
+	}
+
+	pointer := []byte("value")
+	data := testData{
+		Trim:     []byte("  VALUE  "),
+		Truncate: []byte("ябълка"),
+		Raw:      json.RawMessage(" {} "),
+		Pointer:  &pointer,
+		Dive:     [][]byte{[]byte(" a  b "), nil},
+		Slug:     []byte("Hello, World!"),
+	}
+
+	transformer := New()
+	err := transformer.Struct(&data)
+
+	require.Nil(t, err)
+	require.Equal(t, []byte("value"), data.Trim)
+	require.Equal(t, []byte("ябъ"), data.Truncate)
+	require.Equal(t, json.RawMessage("{}"), data.Raw)
+	require.Equal(t, []byte("VALUE"), *data.Pointer)
+	require.Nil(t, data.Nil)
+	require.Equal(t, []byte("value"), data.Default)
+	require.Equal(t, [][]byte{[]byte("a b"), nil}, data.Dive)
+	require.Equal(t, []byte("hello-world"), data.Slug)
+}
+
+func Test_StructWithByteSliceUnexpectedValue(t *testing.T) {
+	type testData struct {
+		Bytes []byte `morph:"ceil"`
+	}
+
+	transformer := New()
+	err := transformer.Struct(&testData{Bytes: []byte("1.5")})
+
+	require.Error(t, err)
+	require.Equal(t, "unexpected value:'slice' for tag: 'ceil'", err.Error())
+}
+
+func Test_StructWithByteSliceCustomTag(t *testing.T) {
+	type testData struct {
+		Bytes []byte `morph:"mirror"`
+	}
+
+	transformer := New()
+	err := transformer.Register("mirror", &funcTransformer{Func: func(value *reflect.Value, _ *string) error {
+		bytes := value.Bytes()
+		for i, j := 0, len(bytes)-1; i < j; i, j = i+1, j-1 {
+			bytes[i], bytes[j] = bytes[j], bytes[i]
+		}
+		return nil
+	}})
+	require.Nil(t, err)
+
+	data := testData{Bytes: []byte{1, 2, 3}}
+	err = transformer.Struct(&data)
+
+	require.Nil(t, err)
+	require.Equal(t, []byte{3, 2, 1}, data.Bytes)
+}
+
+func Test_StructWithTagJSON(t *testing.T) {
+	type testData struct {
+		Compact   string          `morph:"json"`
+		Raw       json.RawMessage `morph:"json"`
+		Canonical json.RawMessage `morph:"json=canonical"`
+		Bytes     []byte          `morph:"json=canonical"`
+		Empty     json.RawMessage `morph:"json"`
+		HTML      string          `morph:"json=canonical"`
+	}
+
+	data := testData{
+		Compact:   "{ \"b\": 1.0,\n \"a\": [1, 2] }",
+		Raw:       json.RawMessage(" [ true, null ] "),
+		Canonical: json.RawMessage(`{"b": {"d": 1, "c": 2}, "a": [1.50E+2, -0.0, 0.000001, 1e-7, 1e21, 12345678901234567890.0]}`),
+		Bytes:     []byte(`{"z": "é", "y": 100e-2, "x": -12.3400}`),
+		HTML:      `{"html": "<b>&amp;</b>"}`,
+	}
+
+	transformer := New()
+	err := transformer.Struct(&data)
+
+	require.Nil(t, err)
+	require.Equal(t, `{"b":1.0,"a":[1,2]}`, data.Compact)
+	require.Equal(t, json.RawMessage(`[true,null]`), data.Raw)
+	require.Equal(t, json.RawMessage(`{"a":[150,0,0.000001,1e-7,1e+21,12345678901234567890],"b":{"c":2,"d":1}}`), data.Canonical)
+	require.Equal(t, []byte(`{"x":-12.34,"y":1,"z":"é"}`), data.Bytes)
+	require.Nil(t, data.Empty)
+	require.Equal(t, `{"html":"<b>&amp;</b>"}`, data.HTML)
+}
+
+func Test_StructWithTagJSONInvalid(t *testing.T) {
+	type testData struct {
+		Compact   string `morph:"json"`
+		Canonical string `morph:"json=canonical"`
+	}
+
+	type paramsData struct {
+		JSON string `morph:"json=baba"`
+	}
+
+	transformer := New()
+	for _, value := range []string{"{", "{\"a\": }", "{} {}", "[1,]"} {
+		for i := 0; i < 2; i++ {
+			data := testData{}
+			field := reflect.ValueOf(&data).Elem().Field(i)
+			field.SetString(value)
+
+			err := transformer.Struct(&data)
+			require.Error(t, err, value)
+			require.Contains(t, err.Error(), "invalid value")
+			require.Equal(t, value, field.String())
+		}
+	}
+
+	for _, value := range []string{"[1e99999999999999999999]", "[1.5e-2147483649]"} {
+		data := testData{Canonical: value}
+		err := transformer.Struct(&data)
+
+		require.Error(t, err, value)
+		require.Equal(t, fmt.Sprintf("invalid value: '%s' for tag: 'json'", value), err.Error())
+		require.Equal(t, value, data.Canonical)
+	}
+
+	data := testData{Canonical: "[1.5e2147483647, 1.5e-2147483648]"}
+	require.Nil(t, transformer.Struct(&data))
+	require.Equal(t, "[1.5e+2147483647,1.5e-2147483648]", data.Canonical)
+
+	err := transformer.Struct(&paramsData{})
+	require.Error(t, err)
+	require.Equal(t, "invalid parameters 'baba' for tag: 'json'", err.Error())
+}
+
+//endregion bytes
+
+//region parsing
+
+func Test_StructWithTagNumeric(t *testing.T) {
+	type testData struct {
+		Auto    []string `morph:"dive,numeric"`
+		Dot     []string `morph:"dive,numeric=dot"`
+		Comma   []string `morph:"dive,numeric=comma"`
+		Pointer *string  `morph:"numeric"`
+		Bytes   []byte   `morph:"numeric"`
+		Empty   string   `morph:"numeric"`
+	}
+
+	pointer := "1 234,50"
+	data := testData{
+		Auto: []string{
+			" 12,5 ", "12.50", "0042", "-0,000", ".5", "5.", "-1 234,5", "−3.10", "+7", "1.234.567,89",
+			"1,234,567.89", "1'234.5", "1.234.567", "1,234",
+		},
+		Dot:     []string{"1,234", "1,234.50", "0.1"},
+		Comma:   []string{"1.234", "1.234,50", "0,1"},
+		Pointer: &pointer,
+		Bytes:   []byte("2,50"),
+		Empty:   "  ",
+	}
+
+	transformer := New()
+	err := transformer.Struct(&data)
+
+	require.Nil(t, err)
+	require.Equal(t, []string{
+		"12.5", "12.5", "42", "0", "0.5", "5", "-1234.5", "-3.1", "7", "1234567.89", "1234567.89", "1234.5",
+		"1234567", "1.234",
+	}, data.Auto)
+	require.Equal(t, []string{"1234", "1234.5", "0.1"}, data.Dot)
+	require.Equal(t, []string{"1234", "1234.5", "0.1"}, data.Comma)
+	require.Equal(t, "1234.5", *data.Pointer)
+	require.Equal(t, []byte("2.5"), data.Bytes)
+	require.Equal(t, "", data.Empty)
+}
+
+func Test_StructWithTagNumericInvalidValue(t *testing.T) {
+	for _, value := range []string{
+		"abc", "12a", "1,2,3", "1.234,5,6", "1,23.456", "12345,678.5", "1 234.567,5", "1..2", ",", "-", "--1", "1e5",
+		"1 234'567",
+	} {
+		data := struct {
+			Value string `morph:"numeric"`
+		}{value}
+
+		err := New().Struct(&data)
+
+		require.Error(t, err, value)
+		require.Equal(t, "invalid value: '"+value+"' for tag: 'numeric'", err.Error())
+	}
+}
+
+func Test_StructWithTagNumericInvalidParameters(t *testing.T) {
+	type testData struct {
+		Value string `morph:"numeric=space"`
+	}
+
+	transformer := New()
+	err := transformer.Struct(&testData{})
+
+	require.Error(t, err)
+	require.Equal(t, "invalid parameters 'space' for tag: 'numeric'", err.Error())
+}
+
+func Test_StructWithTagNumericWithDotDecimal(t *testing.T) {
+	type testData struct {
+		Value string `morph:"numeric=dot"`
+	}
+
+	transformer := New()
+	err := transformer.Struct(&testData{Value: "1,5"})
+
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid value")
+}
+
+func Test_StructWithTagBool(t *testing.T) {
+	type testData struct {
+		True  []string `morph:"dive,bool"`
+		False []string `morph:"dive,bool"`
+		Empty string   `morph:"bool"`
+	}
+
+	data := testData{
+		True:  []string{" Yes ", "y", "ON", "1", "true", "T"},
+		False: []string{"no", "N", "off", "0", "False", "f"},
+		Empty: " ",
+	}
+
+	transformer := New()
+	err := transformer.Struct(&data)
+
+	require.Nil(t, err)
+	require.Equal(t, []string{"true", "true", "true", "true", "true", "true"}, data.True)
+	require.Equal(t, []string{"false", "false", "false", "false", "false", "false"}, data.False)
+	require.Equal(t, "", data.Empty)
+}
+
+func Test_StructWithTagBoolInvalidValue(t *testing.T) {
+	type testData struct {
+		Value string `morph:"bool"`
+	}
+
+	transformer := New()
+	err := transformer.Struct(&testData{Value: "maybe"})
+
+	require.Error(t, err)
+	require.Equal(t, "invalid value: 'maybe' for tag: 'bool'", err.Error())
+}
+
+func Test_StructWithTagBoolUnexpectedValue(t *testing.T) {
+	type testData struct {
+		Value bool `morph:"bool"`
+	}
+
+	transformer := New()
+	err := transformer.Struct(&testData{})
+
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "unexpected value")
+}
+
+func Test_StructWithTagDate(t *testing.T) {
+	type testData struct {
+		Dates    []string `morph:"dive,date=2006-01-02"`
+		Custom   string   `morph:"date=2006-01-02|01/02/2006"`
+		Time     string   `morph:"date=2006-01-02T15:04:05Z07:00"`
+		Readable string   `morph:"date=2 January 2006"`
+		Empty    string   `morph:"date=2006-01-02"`
+	}
+
+	data := testData{
+		Dates: []string{
+			" 2022-06-15 ", "2022-06-15T10:30:00Z", "2022-06-15 10:30:00", "2022/06/15", "20220615", "15.06.2022",
+			"5.6.2022", "15 Jun 2022", "15 june 2022", "Jun 15 2022", "June 15, 2022", "Wed, 15 Jun 2022 10:30:00 GMT",
+		},
+		Custom:   "06/15/2022",
+		Time:     "2022-06-15 10:30:00+02:00",
+		Readable: "2022-06-15",
+	}
+
+	transformer := New()
+	err := transformer.Struct(&data)
+
+	require.Nil(t, err)
+	require.Equal(t, []string{
+		"2022-06-15", "2022-06-15", "2022-06-15", "2022-06-15", "2022-06-15", "2022-06-15", "2022-06-05",
+		"2022-06-15", "2022-06-15", "2022-06-15", "2022-06-15", "2022-06-15",
+	}, data.Dates)
+	require.Equal(t, "2022-06-15", data.Custom)
+	require.Equal(t, "2022-06-15T10:30:00+02:00", data.Time)
+	require.Equal(t, "15 June 2022", data.Readable)
+	require.Equal(t, "", data.Empty)
+}
+
+func Test_StructWithTagDateInvalidValue(t *testing.T) {
+	type testData struct {
+		Value string `morph:"date=2006-01-02"`
+	}
+
+	transformer := New()
+	err := transformer.Struct(&testData{Value: "06/15/2022"})
+
+	require.Error(t, err)
+	require.Equal(t, "invalid value: '06/15/2022' for tag: 'date'", err.Error())
+}
+
+func Test_StructWithTagDateMissingParameters(t *testing.T) {
+	tests := []struct {
+		name string
+		data interface{}
+	}{
+		{"missing", &struct {
+			Value string `morph:"date"`
+		}{}},
+		{"empty layout", &struct {
+			Value string `morph:"date=2006-01-02|"`
+		}{}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := New().Struct(test.data)
+
+			require.Error(t, err)
+			require.Equal(t, "missing parameters for tag: date", err.Error())
+		})
+	}
+}
+
+//endregion parsing
+
+//region collections
+
+func Test_StructWithCollectionTags(t *testing.T) {
+	type item struct {
+		Name string
+	}
+
+	type testData struct {
+		Labels   []string    `morph:"dive,trim,lower,exit,compact,unique,sort"`
+		Unique   []int       `morph:"unique"`
+		Items    []*item     `morph:"unique"`
+		Sort     []float64   `morph:"sort"`
+		SortDesc []string    `morph:"sortdesc"`
+		Times    []time.Time `morph:"sortdesc"`
+		Compact  [][]string  `morph:"compact"`
+		Limit    []int       `morph:"limit=2"`
+		Short    []int       `morph:"limit=5"`
+		Reverse  []string    `morph:"reverse"`
+		Bytes    []byte      `morph:"unique,sort,reverse"`
+		Pointer  *[]string   `morph:"dive,trim,exit,unique"`
+		Nil      []string    `morph:"unique,sort,compact,limit=1,reverse"`
+	}
+
+	first := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	second := first.Add(time.Hour)
+	pointer := []string{"a ", " a", "b"}
+	data := testData{
+		Labels:   []string{" Go ", "", "rust", "go", "  "},
+		Unique:   []int{3, 1, 3, 2, 1},
+		Items:    []*item{{"a"}, {"b"}, {"a"}, nil, nil},
+		Sort:     []float64{2.5, -1, math.NaN(), 0},
+		SortDesc: []string{"b", "c", "a"},
+		Times:    []time.Time{first, second},
+		Compact:  [][]string{nil, {}, {"a"}},
+		Limit:    []int{1, 2, 3},
+		Short:    []int{1, 2, 3},
+		Reverse:  []string{"a", "b", "c"},
+		Bytes:    []byte("hello"),
+		Pointer:  &pointer,
+	}
+
+	transformer := New()
+	err := transformer.Struct(&data)
+
+	require.Nil(t, err)
+	require.Equal(t, []string{"go", "rust"}, data.Labels)
+	require.Equal(t, []int{3, 1, 2}, data.Unique)
+	require.Equal(t, []*item{{"a"}, {"b"}, nil}, data.Items)
+	require.True(t, math.IsNaN(data.Sort[0]))
+	require.Equal(t, []float64{-1, 0, 2.5}, data.Sort[1:])
+	require.Equal(t, []string{"c", "b", "a"}, data.SortDesc)
+	require.Equal(t, []time.Time{second, first}, data.Times)
+	require.Equal(t, [][]string{{"a"}}, data.Compact)
+	require.Equal(t, []int{1, 2}, data.Limit)
+	require.Equal(t, 2, cap(data.Limit))
+	require.Equal(t, []int{1, 2, 3}, data.Short)
+	require.Equal(t, []string{"c", "b", "a"}, data.Reverse)
+	require.Equal(t, []byte("olhe"), data.Bytes)
+	require.Equal(t, []string{"a", "b"}, *data.Pointer)
+	require.Nil(t, data.Nil)
+}
+
+func Test_StructWithTagSortNamedTime(t *testing.T) {
+	type stamp time.Time
+	type testData struct {
+		Times []stamp `morph:"sort"`
+	}
+
+	first := stamp(time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC))
+	second := stamp(time.Date(2022, 1, 2, 0, 0, 0, 0, time.UTC))
+	data := testData{
+		Times: []stamp{second, first},
+	}
+
+	transformer := New()
+	err := transformer.Struct(&data)
+
+	require.Nil(t, err)
+	require.Equal(t, []stamp{first, second}, data.Times)
+}
+
+func Test_StructWithCollectionTagsInsideDive(t *testing.T) {
+	type testData struct {
+		Groups [][]string          `morph:"dive,dive,trim,exit,unique,sort,exit,limit=2"`
+		Map    map[string][]string `morph:"dive,keys,lower,exit,dive,trim,exit,unique"`
+	}
+
+	data := testData{
+		Groups: [][]string{{"b ", "a", " b"}, {"c"}, {"d"}},
+		Map:    map[string][]string{"KEY": {"a ", "a"}},
+	}
+
+	transformer := New()
+	err := transformer.Struct(&data)
+
+	require.Nil(t, err)
+	require.Equal(t, [][]string{{"a", "b"}, {"c"}}, data.Groups)
+	require.Equal(t, map[string][]string{"key": {"a"}}, data.Map)
+}
+
+func Test_StructWithTagNonNil(t *testing.T) {
+	type testData struct {
+		Slice   []string         `morph:"nonnil"`
+		Map     map[string]int   `morph:"nonnil"`
+		Pointer *[]int           `morph:"dive,abs,exit,nonnil"`
+		Kept    []string         `morph:"nonnil"`
+		Nested  map[string][]int `morph:"nonnil,dive,nonnil"`
+	}
+
+	data := testData{
+		Kept:   []string{"a"},
+		Nested: nil,
+	}
+
+	transformer := New()
+	err := transformer.Struct(&data)
+
+	require.Nil(t, err)
+	require.NotNil(t, data.Slice)
+	require.Empty(t, data.Slice)
+	require.NotNil(t, data.Map)
+	require.Empty(t, data.Map)
+	require.NotNil(t, data.Pointer)
+	require.NotNil(t, *data.Pointer)
+	require.Empty(t, *data.Pointer)
+	require.Equal(t, []string{"a"}, data.Kept)
+	require.Equal(t, map[string][]int{}, data.Nested)
+
+	encoded, err := json.Marshal(data)
+	require.Nil(t, err)
+	require.Equal(t, `{"Slice":[],"Map":{},"Pointer":[],"Kept":["a"],"Nested":{}}`, string(encoded))
+}
+
+func Test_StructWithCollectionTagsInvalidParameters(t *testing.T) {
+	tests := []struct {
+		name   string
+		data   interface{}
+		errMsg string
+	}{
+		{"negative limit", &struct {
+			Items []int `morph:"limit=-1"`
+		}{}, "invalid parameters '-1' for tag: 'limit'"},
+		{"invalid limit", &struct {
+			Items []int `morph:"limit=all"`
+		}{}, "invalid parameters 'all' for tag: 'limit'"},
+		{"missing limit", &struct {
+			Items []int `morph:"limit"`
+		}{}, "missing parameters for tag: limit"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := New().Struct(test.data)
+
+			require.Error(t, err)
+			require.Equal(t, test.errMsg, err.Error())
+		})
+	}
+}
+
+func Test_StructWithCollectionTagsUnexpectedValue(t *testing.T) {
+	tests := []struct {
+		name   string
+		data   interface{}
+		errMsg string
+	}{
+		{"unique string", &struct {
+			Value string `morph:"unique"`
+		}{"a"}, "unexpected value:'string' for tag: 'unique'"},
+		{"reverse array", &struct {
+			Value [2]int `morph:"reverse"`
+		}{}, "unexpected value:'array' for tag: 'reverse'"},
+		{"sort structs", &struct {
+			Value []struct{ Name string } `morph:"sort"`
+		}{[]struct{ Name string }{{"a"}}}, "unexpected value:'struct' for tag: 'sort'"},
+		{"nonnil int", &struct {
+			Value int `morph:"nonnil"`
+		}{}, "unexpected value:'int' for tag: 'nonnil'"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := New().Struct(test.data)
+
+			require.Error(t, err)
+			require.Equal(t, test.errMsg, err.Error())
+		})
+	}
+}
+
+func Test_StructWithExitOutsideDive(t *testing.T) {
+	type testData struct {
+		Value string `morph:"trim,exit,upper"`
+	}
+
+	data := testData{Value: " value "}
+
+	transformer := New()
+	err := transformer.Struct(&data)
+
+	require.Nil(t, err)
+	require.Equal(t, "VALUE", data.Value)
+}
+
+func Test_FromAfterDiveExit(t *testing.T) {
+	type testData struct {
+		Source []string
+		Target []string `morph:"dive,trim,exit,from=Source,unique"`
+	}
+
+	data := testData{
+		Source: []string{"a", "a", "b"},
+		Target: []string{" c "},
+	}
+
+	transformer := New()
+	err := transformer.Struct(&data)
+
+	require.Nil(t, err)
+	require.Equal(t, []string{"a", "a", "b"}, data.Source)
+	require.Equal(t, []string{"a", "b"}, data.Target)
+}
+
+func Test_FromInsideDiveExit(t *testing.T) {
+	type testData struct {
+		Source string
+		Target [][]string `morph:"dive,dive,from=Source,exit,exit"`
+	}
+
+	transformer := New()
+	err := transformer.Struct(&testData{})
+
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "cannot be used inside dive")
+}
+
+//endregion collections
+
+//region key collisions
+
+func Test_StructWithKeyCollisionPolicies(t *testing.T) {
+	type testData struct {
+		Default   map[string]int `morph:"dive,keys,trim,lower,exit"`
+		KeepLast  map[string]int `morph:"dive,keys=keeplast,trim,lower,exit"`
+		KeepFirst map[string]int `morph:"dive,keys=keepfirst,trim,lower,exit"`
+		Values    map[string]int `morph:"dive,keys=keepfirst,lower,exit,abs"`
+		Distinct  map[string]int `morph:"dive,keys=error,trim,exit"`
+	}
+
+	transformer := New()
+
+	// the maps are built and morphed repeatedly, so that the results don't depend on the order of their iteration
+	for i := 0; i < 100; i++ {
+		data := testData{
+			Default:   map[string]int{" A": 1, "a": 2, "A ": 3, "b": 4},
+			KeepLast:  map[string]int{" A": 1, "a": 2, "A ": 3, "b": 4},
+			KeepFirst: map[string]int{" A": 1, "a": 2, "A ": 3, "b": 4},
+			Values:    map[string]int{"B": -1, "b": -2},
+			Distinct:  map[string]int{" a": 1, "b ": 2},
+		}
+
+		err := transformer.Struct(&data)
+
+		require.Nil(t, err)
+		require.Equal(t, map[string]int{"a": 2, "b": 4}, data.Default)
+		require.Equal(t, map[string]int{"a": 2, "b": 4}, data.KeepLast)
+		require.Equal(t, map[string]int{"a": 1, "b": 4}, data.KeepFirst)
+		require.Equal(t, map[string]int{"b": 1}, data.Values)
+		require.Equal(t, map[string]int{"a": 1, "b": 2}, data.Distinct)
+	}
+}
+
+func Test_StructWithKeyCollisionError(t *testing.T) {
+	type testData struct {
+		Map map[string]int `morph:"dive,keys=error,lower,exit"`
+	}
+
+	data := testData{Map: map[string]int{"A": 1, "a": 2, "b": 3}}
+
+	transformer := New()
+	err := transformer.Struct(&data)
+
+	require.Error(t, err)
+	require.Equal(t, "key collision: 'a' for tag: 'keys'", err.Error())
+	require.Equal(t, map[string]int{"A": 1, "a": 2, "b": 3}, data.Map)
+}
+
+func Test_StructWithKeyCollisionMerge(t *testing.T) {
+	type testData struct {
+		Sum    map[string]int      `morph:"dive,keys=merge=sum,lower,exit"`
+		Join   map[string][]string `morph:"dive,keys=merge=join,trim,exit,dive,upper"`
+		Nested []map[int]int       `morph:"dive,dive,keys=merge=sum,abs,exit"`
+	}
+
+	transformer := New()
+	require.Nil(t, transformer.RegisterKeyMerger("sum", func(_, merged, value interface{}) (interface{}, error) {
+		return merged.(int) + value.(int), nil
+	}))
+	require.Nil(t, transformer.RegisterKeyMerger("join", func(key, merged, value interface{}) (interface{}, error) {
+		return append(merged.([]string), value.([]string)...), nil
+	}))
+
+	for i := 0; i < 100; i++ {
+		data := testData{
+			Sum:    map[string]int{"A": 1, "a": 2, "b": 3},
+			Join:   map[string][]string{"a ": {"c"}, " a": {"b"}, "a": {"a"}},
+			Nested: []map[int]int{{-1: 1, 1: 2}},
+		}
+
+		err := transformer.Struct(&data)
+
+		require.Nil(t, err)
+		require.Equal(t, map[string]int{"a": 3, "b": 3}, data.Sum)
+		require.Equal(t, map[string][]string{"a": {"B", "A", "C"}}, data.Join)
+		require.Equal(t, []map[int]int{{1: 3}}, data.Nested)
+	}
+}
+
+func Test_StructWithKeyCollisionMergeErrors(t *testing.T) {
+	type testData struct {
+		Map map[string]int `morph:"dive,keys=merge=fail,lower,exit"`
+	}
+
+	type invalidData struct {
+		Map map[string]int `morph:"dive,keys=merge=invalid,lower,exit"`
+	}
+
+	transformer := New()
+	require.Nil(t, transformer.RegisterKeyMerger("fail", func(key, _, _ interface{}) (interface{}, error) {
+		return nil, fmt.Errorf("cannot merge %s", key)
+	}))
+	require.Nil(t, transformer.RegisterKeyMerger("invalid", func(_, _, _ interface{}) (interface{}, error) {
+		return "value", nil
+	}))
+
+	err := transformer.Struct(&testData{Map: map[string]int{"A": 1, "a": 2}})
+
+	require.Error(t, err)
+	require.Equal(t, "cannot merge a", err.Error())
+
+	err = transformer.Struct(&invalidData{Map: map[string]int{"A": 1, "a": 2}})
+
+	require.Error(t, err)
+	require.Equal(t, "unexpected value:'string' for tag: 'keys'", err.Error())
+}
+
+func Test_StructWithKeyCollisionInvalidParameters(t *testing.T) {
+	tests := []struct {
+		name   string
+		data   interface{}
+		errMsg string
+	}{
+		{"unknown policy", &struct {
+			Map map[string]int `morph:"dive,keys=keepall,lower,exit"`
+		}{}, "invalid parameters 'keepall' for tag: 'keys'"},
+		{"policy value", &struct {
+			Map map[string]int `morph:"dive,keys=error=sum,lower,exit"`
+		}{}, "invalid parameters 'error=sum' for tag: 'keys'"},
+		{"missing merger", &struct {
+			Map map[string]int `morph:"dive,keys=merge,lower,exit"`
+		}{}, "missing parameters for tag: keys"},
+		{"unknown merger", &struct {
+			Map map[string]int `morph:"dive,keys=merge=sum,lower,exit"`
+		}{}, "unknown merger: 'sum' for tag: 'keys'"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := New().Struct(test.data)
+
+			require.Error(t, err)
+			require.Equal(t, test.errMsg, err.Error())
+		})
+	}
+}
+
+func Test_RegisterKeyMergerInvalid(t *testing.T) {
+	transformer := New()
+
+	err := transformer.RegisterKeyMerger(" ", func(_, merged, _ interface{}) (interface{}, error) {
+		return merged, nil
+	})
+
+	require.Error(t, err)
+	require.Equal(t, "invalid merger name", err.Error())
+
+	err = transformer.RegisterKeyMerger("merger", nil)
+
+	require.Error(t, err)
+	require.Equal(t, "invalid merger", err.Error())
+}
+
+//endregion key collisions
+
+//region maps
+
+func Test_StructWithMapTags(t *testing.T) {
+	type testData struct {
+		Allow     map[string]int    `morph:"allowkeys=a b"`
+		Deny      map[string]int    `morph:"denykeys=a b"`
+		IntKeys   map[int]string    `morph:"allowkeys=1 3"`
+		DropEmpty map[string]string `morph:"dive,keys,trim,lower,exit,trim,exit,dropempty"`
+		Slices    map[string][]int  `morph:"dropempty"`
+		Prefix    map[string]int    `morph:"keyprefix=x_"`
+		Strip     map[string]int    `morph:"stripkeyprefix=x_"`
+		MaxKeys   map[string]int    `morph:"maxkeys=2"`
+		Combined  map[string]string `morph:"dive,trim,exit,dropempty,stripkeyprefix=ext_,denykeys=id,maxkeys=2"`
+		Pointer   *map[string]int   `morph:"allowkeys=a"`
+		Nil       map[string]int    `morph:"dropempty,allowkeys=a,keyprefix=x_,maxkeys=1"`
+	}
+
+	pointer := map[string]int{"a": 1, "b": 2}
+	data := testData{
+		Allow:     map[string]int{"a": 1, "b": 2, "c": 3},
+		Deny:      map[string]int{"a": 1, "b": 2, "c": 3},
+		IntKeys:   map[int]string{1: "a", 2: "b", 3: "c"},
+		DropEmpty: map[string]string{" A ": " value ", "b": "  ", "c": ""},
+		Slices:    map[string][]int{"a": {}, "b": nil, "c": {1}},
+		Prefix:    map[string]int{"a": 1, "b": 2},
+		Strip:     map[string]int{"x_a": 1, "b": 2, "x_b": 3},
+		MaxKeys:   map[string]int{"c": 3, "a": 1, "b": 2},
+		Combined:  map[string]string{"ext_id": "1", "ext_name": " name ", "ext_note": " ", "z": "z", "a": "a"},
+		Pointer:   &pointer,
+	}
+
+	transformer := New()
+	err := transformer.Struct(&data)
+
+	require.Nil(t, err)
+	require.Equal(t, map[string]int{"a": 1, "b": 2}, data.Allow)
+	require.Equal(t, map[string]int{"c": 3}, data.Deny)
+	require.Equal(t, map[int]string{1: "a", 3: "c"}, data.IntKeys)
+	require.Equal(t, map[string]string{"a": "value"}, data.DropEmpty)
+	require.Equal(t, map[string][]int{"c": {1}}, data.Slices)
+	require.Equal(t, map[string]int{"x_a": 1, "x_b": 2}, data.Prefix)
+	require.Equal(t, map[string]int{"a": 1, "b": 3}, data.Strip)
+	require.Equal(t, map[string]int{"a": 1, "b": 2}, data.MaxKeys)
+	require.Equal(t, map[string]string{"a": "a", "name": "name"}, data.Combined)
+	require.Equal(t, map[string]int{"a": 1}, *data.Pointer)
+	require.Nil(t, data.Nil)
+}
+
+func Test_StructWithMapTagsFrom(t *testing.T) {
+	type testData struct {
+		Source map[string]int
+		Target map[string]int `morph:"from=Source,denykeys=a"`
+	}
+
+	data := testData{Source: map[string]int{"a": 1, "b": 2}}
+
+	transformer := New()
+	err := transformer.Struct(&data)
+
+	require.Nil(t, err)
+	require.Equal(t, map[string]int{"a": 1, "b": 2}, data.Source)
+	require.Equal(t, map[string]int{"b": 2}, data.Target)
+}
+
+func Test_StructWithTagAllowKeysStringer(t *testing.T) {
+	type testData struct {
+		Allow map[mapLevel]int `morph:"allowkeys=1"`
+		Deny  map[mapLevel]int `morph:"denykeys=high"`
+	}
+
+	data := testData{
+		Allow: map[mapLevel]int{1: 1, 2: 2},
+		Deny:  map[mapLevel]int{1: 1, 2: 2},
+	}
+
+	transformer := New()
+	err := transformer.Struct(&data)
+
+	require.Nil(t, err)
+	require.Equal(t, map[mapLevel]int{1: 1}, data.Allow)
+	require.Equal(t, map[mapLevel]int{1: 1, 2: 2}, data.Deny)
+}
+
+type mapLevel int
+
+func (l mapLevel) String() string {
+	if l > 1 {
+		return "high"
+	}
+
+	return "low"
+}
+
+func Test_StructWithMapTagsInsideDive(t *testing.T) {
+	type testData struct {
+		Items []map[string]int `morph:"dive,dropempty,keyprefix=item_,exit,compact"`
+	}
+
+	data := testData{Items: []map[string]int{{"a": 1, "b": 0}, {"c": 0}}}
+
+	transformer := New()
+	err := transformer.Struct(&data)
+
+	require.Nil(t, err)
+	require.Equal(t, []map[string]int{{"item_a": 1}}, data.Items)
+}
+
+func Test_StructWithMapTagsInvalidParameters(t *testing.T) {
+	tests := []struct {
+		name   string
+		data   interface{}
+		errMsg string
+	}{
+		{"missing allowed keys", &struct {
+			Map map[string]int `morph:"allowkeys= "`
+		}{}, "missing parameters for tag: allowkeys"},
+		{"missing denied keys", &struct {
+			Map map[string]int `morph:"denykeys"`
+		}{}, "missing parameters for tag: denykeys"},
+		{"missing prefix", &struct {
+			Map map[string]int `morph:"keyprefix="`
+		}{}, "missing parameters for tag: keyprefix"},
+		{"missing stripped prefix", &struct {
+			Map map[string]int `morph:"stripkeyprefix"`
+		}{}, "missing parameters for tag: stripkeyprefix"},
+		{"invalid count", &struct {
+			Map map[string]int `morph:"maxkeys=-2"`
+		}{}, "invalid parameters '-2' for tag: 'maxkeys'"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := New().Struct(test.data)
+
+			require.Error(t, err)
+			require.Equal(t, test.errMsg, err.Error())
+		})
+	}
+}
+
+func Test_StructWithMapTagsUnexpectedValue(t *testing.T) {
+	tests := []struct {
+		name   string
+		data   interface{}
+		errMsg string
+	}{
+		{"slice", &struct {
+			Value []string `morph:"dropempty"`
+		}{}, "unexpected value:'slice' for tag: 'dropempty'"},
+		{"string", &struct {
+			Value string `morph:"maxkeys=1"`
+		}{}, "unexpected value:'string' for tag: 'maxkeys'"},
+		{"int keys", &struct {
+			Value map[int]string `morph:"keyprefix=x_"`
+		}{}, "unexpected value:'int' for tag: 'keyprefix'"},
+		{"float keys", &struct {
+			Value map[float64]string `morph:"allowkeys=1"`
+		}{Value: map[float64]string{1: "a"}}, "unexpected value:'float64' for tag: 'allowkeys'"},
+		{"struct keys", &struct {
+			Value map[struct{ ID int }]string `morph:"denykeys=1"`
+		}{}, "unexpected value:'struct' for tag: 'denykeys'"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := New().Struct(test.data)
+
+			require.Error(t, err)
+			require.Equal(t, test.errMsg, err.Error())
+		})
+	}
+}
+
+//endregion maps
+
+//region upper
+
+func Test_StructWithTagUpper(t *testing.T) {
+	type testData struct {
+		String string `morph:"upper"`
+	}
+	data := testData{
+		String: "data",
+	}
+
+	transformer := New()
+	err := transformer.Struct(&data)
+
+	require.Nil(t, err)
+	require.Equal(t, "DATA", data.String)
+}
+
+//endregion upper
+
+//region case
+
+func Test_StructWithTagLowerUpperLocale(t *testing.T) {
+	type testData struct {
+		Lower       string `morph:"lower=tr"`
+		Upper       string `morph:"upper=tr"`
+		LowerAzeri  string `morph:"lower=az"`
+		LowerNormal string `morph:"lower"`
+	}
+
+	data := testData{
+		Lower:       "KIZ İSTANBUL",
+		Upper:       "kız istanbul",
+		LowerAzeri:  "I",
+		LowerNormal: "KIZ",
+	}
+
+	transformer := New()
+	err := transformer.Struct(&data)
+
+	require.Nil(t, err)
+	require.Equal(t, "kız istanbul", data.Lower)
+	require.Equal(t, "KIZ İSTANBUL", data.Upper)
+	require.Equal(t, "ı", data.LowerAzeri)
+	require.Equal(t, "kiz", data.LowerNormal)
+}
+
+func Test_StructWithTagLowerUnknownLocale(t *testing.T) {
+	type testData struct {
+		String string `morph:"lower=baba"`
+	}
+
+	transformer := New()
+	err := transformer.Struct(&testData{})
+
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid parameters")
+	require.Contains(t, err.Error(), "baba")
+}
+
+func Test_StructWithTagTitleSentence(t *testing.T) {
+	type testData struct {
+		Title    string `morph:"title"`
+		Sentence string `morph:"sentence"`
+	}
+
+	data := testData{
+		Title:    "hello WORLD, здравей  свят",
+		Sentence: "HELLO THERE. how are you? fine!  bye",
+	}
+
+	transformer := New()
+	err := transformer.Struct(&data)
+
+	require.Nil(t, err)
+	require.Equal(t, "Hello World, Здравей  Свят", data.Title)
+	require.Equal(t, "Hello there. How are you? Fine!  Bye", data.Sentence)
+}
+
+func Test_StructWithTagSentenceInnerPunctuation(t *testing.T) {
+	type testData struct {
+		Sentences []string `morph:"dive,sentence"`
+	}
+
+	data := testData{
+		Sentences: []string{"PI IS 3.14 OK. yes", "use node.js! now", "e.g.it works"},
+	}
+
+	transformer := New()
+	err := transformer.Struct(&data)
+
+	require.Nil(t, err)
+	require.Equal(t, []string{"Pi is 3.14 ok. Yes", "Use node.js! Now", "E.g.it works"}, data.Sentences)
+}
+
+func Test_StructWithTagIdentifierCases(t *testing.T) {
+	type testData struct {
+		Camel    []string `morph:"dive,camel"`
+		Pascal   []string `morph:"dive,pascal"`
+		Snake    []string `morph:"dive,snake"`
+		Kebab    []string `morph:"dive,kebab"`
+		Constant []string `morph:"dive,constant"`
+	}
+
+	values := []string{"HTTPServer", "some_value", "userID2Name", " Здравей свят ", "already-kebab-case"}
+	data := testData{
+		Camel:    append([]string{}, values...),
+		Pascal:   append([]string{}, values...),
+		Snake:    append([]string{}, values...),
+		Kebab:    append([]string{}, values...),
+		Constant: append([]string{}, values...),
+	}
+
+	transformer := New()
+	err := transformer.Struct(&data)
+
+	require.Nil(t, err)
+	require.Equal(t, []string{"httpServer", "someValue", "userId2Name", "здравейСвят", "alreadyKebabCase"}, data.Camel)
+	require.Equal(t, []string{"HttpServer", "SomeValue", "UserId2Name", "ЗдравейСвят", "AlreadyKebabCase"}, data.Pascal)
+	require.Equal(t, []string{"http_server", "some_value", "user_id2_name", "здравей_свят", "already_kebab_case"},
+		data.Snake)
+	require.Equal(t, []string{"http-server", "some-value", "user-id2-name", "здравей-свят", "already-kebab-case"},
+		data.Kebab)
+	require.Equal(t, []string{"HTTP_SERVER", "SOME_VALUE", "USER_ID2_NAME", "ЗДРАВЕЙ_СВЯТ", "ALREADY_KEBAB_CASE"},
+		data.Constant)
+}
+
+func Test_StructWithTagSnakeNamedType(t *testing.T) {
+	type identifier string
+	type testData struct {
+		ID identifier `morph:"snake"`
+	}
+
+	data := testData{
+		ID: "XMLHttpRequest",
+	}
+
+	transformer := New()
+	err := transformer.Struct(&data)
+
+	require.Nil(t, err)
+	require.Equal(t, identifier("xml_http_request"), data.ID)
+}
+
+//endregion case
+
+//region truncate
+
+func Test_StructWithTagTruncateBadParameter(t *testing.T) {
+	type testData struct {
+		String string `morph:"truncate=baba"`
+	}
+
+	data := testData{
+		String: "123456",
+	}
+
+	transformer := New()
+	err := transformer.Struct(&data)
+
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid parameters")
+	require.Contains(t, err.Error(), "baba")
+}
+
+func Test_StructWithTagTruncateNegativeParameter(t *testing.T) {
+	type testData struct {
+		String string `morph:"truncate=-1"`
+	}
+
+	data := testData{
+		String: "123456",
+	}
+
+	transformer := New()
+	err := transformer.Struct(&data)
+
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid parameters")
+}
+
+func Test_StructWithTagTruncate(t *testing.T) {
+	type testData struct {
+		String string `morph:"truncate=5"`
+	}
+	data := testData{
+		String: "123456",
+	}
+
+	transformer := New()
+	err := transformer.Struct(&data)
+
+	require.Nil(t, err)
+	require.Equal(t, "12345", data.String)
+}
+
+func Test_StructWithTagTruncateExact(t *testing.T) {
+	type testData struct {
+		String string `morph:"truncate=6"`
+	}
+	data := testData{
+		String: "123456",
+	}
+
+	transformer := New()
+	err := transformer.Struct(&data)
+
+	require.Nil(t, err)
+	require.Equal(t, "123456", data.String)
+}
+
+func Test_StructWithTagTruncateOneMoreThanExact(t *testing.T) {
+	type testData struct {
+		String string `morph:"truncate=7"`
+	}
+	data := testData{
+		String: "123456",
+	}
+
+	transformer := New()
+	err := transformer.Struct(&data)
+
+	require.Nil(t, err)
+	require.Equal(t, "123456", data.String)
+}
+
+func Test_StructWithTagTruncateRunes(t *testing.T) {
+	type testData struct {
+		Default string `morph:"truncate=3"`
+		Runes   string `morph:"truncate=3 runes"`
+		Bytes   string `morph:"truncate=3 bytes"`
+	}
+
+	data := testData{
+		Default: "ябълка",
+		Runes:   "ябълка",
+		Bytes:   "ябълка",
+	}
+
+	transformer := New()
+	err := transformer.Struct(&data)
+
+	require.Nil(t, err)
+	require.Equal(t, "ябъ", data.Default)
+	require.Equal(t, "ябъ", data.Runes)
+	require.Equal(t, "я", data.Bytes)
+}
+
+func Test_StructWithTagTruncateGraphemes(t *testing.T) {
+	type testData struct {
+		Emojis  string `morph:"truncate=2 graphemes"`
+		Accents string `morph:"truncate=2 graphemes"`
+		Flags   string `morph:"truncate=1 graphemes"`
+		Hangul  string `morph:"truncate=1 graphemes"`
+	}
+
+	data := testData{
+		Emojis:  "👩‍👩‍👧👍🏽👍",
+		Accents: "e\u0301e\u0301e\u0301",
+		Flags:   "🇧🇬🇬🇧",
+		Hangul:  "\u1100\u1161\u11a8\u1100",
+	}
+
+	transformer := New()
+	err := transformer.Struct(&data)
+
+	require.Nil(t, err)
+	require.Equal(t, "👩‍👩‍👧👍🏽", data.Emojis)
+	require.Equal(t, "e\u0301e\u0301", data.Accents)
+	require.Equal(t, "🇧🇬", data.Flags)
+	require.Equal(t, "\u1100\u1161\u11a8", data.Hangul)
+}
+
+func Test_StructWithTagTruncateEllipsis(t *testing.T) {
+	type testData struct {
+		Default string `morph:"truncate=5 ellipsis"`
+		Custom  string `morph:"truncate=5 ellipsis=..."`
+		Short   string `morph:"truncate=5 ellipsis"`
+		Tiny    string `morph:"truncate=2 ellipsis=..."`
+		Bytes   string `morph:"truncate=5 bytes ellipsis"`
+	}
+
+	data := testData{
+		Default: "some value",
+		Custom:  "some value",
+		Short:   "value",
+		Tiny:    "value",
+		Bytes:   "values",
+	}
+
+	transformer := New()
+	err := transformer.Struct(&data)
+
+	require.Nil(t, err)
+	require.Equal(t, "some…", data.Default)
+	require.Equal(t, "so...", data.Custom)
+	require.Equal(t, "value", data.Short)
+	require.Equal(t, "va", data.Tiny)
+	require.Equal(t, "va…", data.Bytes)
+}
+
+func Test_StructWithTagTruncateWords(t *testing.T) {
+	type testData struct {
+		Words       string `morph:"truncate=7 words"`
+		Ellipsis    string `morph:"truncate=12 words ellipsis"`
+		OnBoundary  string `morph:"truncate=4 words"`
+		SingleWord  string `morph:"truncate=4 words"`
+		MultiSpaces string `morph:"truncate=9 words"`
+	}
+
+	data := testData{
+		Words:       "some value",
+		Ellipsis:    "една дълга стойност",
+		OnBoundary:  "some value",
+		SingleWord:  "something",
+		MultiSpaces: "some   value",
+	}
+
+	transformer := New()
+	err := transformer.Struct(&data)
+
+	require.Nil(t, err)
+	require.Equal(t, "some", data.Words)
+	require.Equal(t, "една дълга…", data.Ellipsis)
+	require.Equal(t, "some", data.OnBoundary)
+	require.Equal(t, "some", data.SingleWord)
+	require.Equal(t, "some", data.MultiSpaces)
+}
+
+func Test_StructWithTagTruncateUnknownOption(t *testing.T) {
+	type testData struct {
+		String string `morph:"truncate=5 baba"`
+	}
+
+	transformer := New()
+	err := transformer.Struct(&testData{})
+
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid parameters")
+	require.Contains(t, err.Error(), "baba")
+}
+
+//endregion truncate
+
+//region mixed
+
+func Test_StructWithTagTrimLower_ArrayOfStrings(t *testing.T) {
+	type testData struct {
+		OtherData []string `morph:"dive,trim,lower"`
+	}
+
+	data := testData{
+		OtherData: []string{" DATA ", " DATA2 "},
+	}
+
+	transformer := New()
+	err := transformer.Struct(&data)
+
+	require.Nil(t, err)
+	require.Equal(t, "data", data.OtherData[0])
+	require.Equal(t, "data2", data.OtherData[1])
+}
+
+func Test_StructWithTagTrimLower_ArrayOfArrayOfStringsDoubleDive(t *testing.T) {
+	type testData struct {
+		OtherData [][]string `morph:"dive,dive,trim,lower"`
+	}
+
+	data := testData{
+		OtherData: [][]string{{" DATA ", " DATA2 "}},
+	}
+
+	transformer := New()
+	err := transformer.Struct(&data)
+
+	require.Nil(t, err)
+	require.Equal(t, "data", data.OtherData[0][0])
+	require.Equal(t, "data2", data.OtherData[0][1])
+}
+
+func Test_Struct_MapOfStringsKeysMultipleTransforms(t *testing.T) {
+	type testData struct {
+		Map map[string]string `morph:"dive,keys,trim,lower,exit,trim,lower"`
+	}
+
+	data := testData{
+		Map: map[string]string{
+			" KEY1 ": " VALUE1 ",
+			" KEY2 ": " VALUE2 ",
+		},
+	}
+
+	transformer := New()
+	err := transformer.Struct(&data)
+
+	require.Nil(t, err)
+	require.Equal(t, "value1", data.Map["key1"])
+	require.Equal(t, "value2", data.Map["key2"])
+}
+
+//endregion mixed
+
+//region numbers
+
+func Test_Ceil(t *testing.T) {
+	type testData struct {
+		Numbers64 []float64 `morph:"dive,ceil"`
+		Numbers32 []float32 `morph:"dive,ceil"`
+	}
+
+	data := testData{
+		Numbers64: []float64{
+			1.465,
+			-1.465,
+			1,
+		},
+		Numbers32: []float32{
+			1.465,
+			-1.465,
+			1,
+		},
+	}
+
+	transformer := New()
+	err := transformer.Struct(&data)
+
+	require.Nil(t, err)
+
+	require.Equal(t, float64(2), data.Numbers64[0])
+	require.Equal(t, float64(-1), data.Numbers64[1])
+	require.Equal(t, float64(1), data.Numbers64[2])
+
+	require.Equal(t, float32(2), data.Numbers32[0])
+	require.Equal(t, float32(-1), data.Numbers32[1])
+	require.Equal(t, float32(1), data.Numbers32[2])
+}
+
+func Test_Floor(t *testing.T) {
+	type testData struct {
+		Numbers64 []float64 `morph:"dive,floor"`
+		Numbers32 []float32 `morph:"dive,floor"`
+	}
+
+	data := testData{
+		Numbers64: []float64{
+			1.565,
+			-1.465,
+			1,
+		},
+		Numbers32: []float32{
+			1.465,
+			-1.465,
+			1,
+		},
+	}
+
+	transformer := New()
+	err := transformer.Struct(&data)
+
+	require.Nil(t, err)
+
+	require.Equal(t, float64(1), data.Numbers64[0])
+	require.Equal(t, float64(-2), data.Numbers64[1])
+	require.Equal(t, float64(1), data.Numbers64[2])
+
+	require.Equal(t, float32(1), data.Numbers32[0])
+	require.Equal(t, float32(-2), data.Numbers32[1])
+	require.Equal(t, float32(1), data.Numbers32[2])
+}
+
+func Test_Round(t *testing.T) {
+	type testData struct {
+		Numbers64 []float64 `morph:"dive,round"`
+		Numbers32 []float32 `morph:"dive,round"`
+	}
+
+	data := testData{
+		Numbers64: []float64{
+			1.565,
+			1.456,
+			-1.565,
+			-1.465,
+			1,
+		},
+		Numbers32: []float32{
+			1.565,
+			1.465,
+			-1.565,
+			-1.465,
+			1,
+		},
+	}
+
+	transformer := New()
+	err := transformer.Struct(&data)
+
+	require.Nil(t, err)
+
+	require.Equal(t, float64(2), data.Numbers64[0])
+	require.Equal(t, float64(1), data.Numbers64[1])
+	require.Equal(t, float64(-2), data.Numbers64[2])
+	require.Equal(t, float64(-1), data.Numbers64[3])
+	require.Equal(t, float64(1), data.Numbers64[4])
+
+	require.Equal(t, float32(2), data.Numbers32[0])
+	require.Equal(t, float32(1), data.Numbers32[1])
+	require.Equal(t, float32(-2), data.Numbers32[2])
+	require.Equal(t, float32(-1), data.Numbers32[3])
+	require.Equal(t, float32(1), data.Numbers32[4])
+}
+
+func Test_PrecisionBadParameter(t *testing.T) {
+	type testData struct {
+		Num float64 `morph:"precision=baba"`
+	}
+
+	data := testData{
+		Num: 1.16,
+	}
+
+	transformer := New()
+	err := transformer.Struct(&data)
+
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid parameters")
+	require.Contains(t, err.Error(), "baba")
+}
+
+func Test_PrecisionNoParameter(t *testing.T) {
+	type testData struct {
+		Num float64 `morph:"precision"`
+	}
+
+	data := testData{
+		Num: 1.16,
+	}
+
+	transformer := New()
+	err := transformer.Struct(&data)
+
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid parameters")
+}
+
+func Test_PrecisionMissingParameter(t *testing.T) {
+	type testData struct {
+		Num float64 `morph:"precision="`
+	}
+
+	data := testData{
+		Num: 1.16,
+	}
+
+	transformer := New()
+	err := transformer.Struct(&data)
+
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid parameters")
+}
+
+func Test_Precision(t *testing.T) {
+	type testData struct {
+		Num164 float64 `morph:"precision=1"`
+		Num132 float32 `morph:"precision=1"`
+		Num264 float64 `morph:"precision=2"`
+		Num232 float32 `morph:"precision=2"`
+		Num364 float64 `morph:"precision=0"`
+		Num332 float32 `morph:"precision=0"`
+	}
+
+	data := testData{
+		Num164: 1.16,
+		Num132: 1.16,
+		Num264: 1.167,
+		Num232: 1.167,
+		Num364: 1.9,
+		Num332: 1.9,
+	}
+
+	transformer := New()
+	err := transformer.Struct(&data)
+
+	require.Nil(t, err)
+
+	require.Equal(t, 1.1, data.Num164)
+	require.Equal(t, 1.16, data.Num264)
+	require.Equal(t, float64(1), data.Num364)
+	require.Equal(t, float32(1.1), data.Num132)
+	require.Equal(t, float32(1.16), data.Num232)
+	require.Equal(t, float32(1), data.Num332)
+}
+
+func Test_PrecisionOnZeroValue(t *testing.T) {
+	type testData struct {
+		Num1 float64 `morph:"precision=1"`
+		Num2 float32 `morph:"precision=1"`
+	}
+
+	data := testData{
+		Num1: 0.0,
+		Num2: 0.0,
+	}
+
+	transformer := New()
+	err := transformer.Struct(&data)
+
+	require.Nil(t, err)
+
+	require.Equal(t, 0.0, data.Num1)
+	require.Equal(t, float32(0.0), data.Num2)
+}
+
+func Test_PrecisionRoundingModes(t *testing.T) {
+	type testData struct {
+		HalfUp      []float64 `morph:"dive,precision=2 half_up"`
+		HalfEven    []float64 `morph:"dive,precision=2 half_even"`
+		Down        []float64 `morph:"dive,precision=2 down"`
+		Up          []float64 `morph:"dive,precision=2 up"`
+		Ceil        []float64 `morph:"dive,precision=2 ceil"`
+		Floor       []float64 `morph:"dive,precision=2 floor"`
+		HalfUp32    float32   `morph:"precision=2 half_up"`
+		Hundreds    int       `morph:"precision=-2 half_even"`
+		Large       float64   `morph:"precision=2 half_up"`
+		NotAffected float64   `morph:"precision=2 half_up"`
+	}
+
+	data := testData{
+		HalfUp:      []float64{1.005, -1.005, 1.004},
+		HalfEven:    []float64{1.005, 1.015, -1.025},
+		Down:        []float64{1.009, -1.009},
+		Up:          []float64{1.001, -1.001},
+		Ceil:        []float64{1.001, -1.009},
+		Floor:       []float64{1.009, -1.001},
+		HalfUp32:    1.005,
+		Hundreds:    250,
+		Large:       1e20 + 0.5,
+		NotAffected: 1.1,
+	}
+
+	transformer := New()
+	err := transformer.Struct(&data)
+
+	require.Nil(t, err)
+	require.Equal(t, []float64{1.01, -1.01, 1}, data.HalfUp)
+	require.Equal(t, []float64{1, 1.02, -1.02}, data.HalfEven)
+	require.Equal(t, []float64{1, -1}, data.Down)
+	require.Equal(t, []float64{1.01, -1.01}, data.Up)
+	require.Equal(t, []float64{1.01, -1}, data.Ceil)
+	require.Equal(t, []float64{1, -1.01}, data.Floor)
+	require.Equal(t, float32(1.01), data.HalfUp32)
+	require.Equal(t, 200, data.Hundreds)
+	require.Equal(t, 1e20, data.Large)
+	require.Equal(t, 1.1, data.NotAffected)
+}
+
+func Test_PrecisionBigNumbers(t *testing.T) {
+	type testData struct {
+		Float *big.Float `morph:"precision=2 half_up"`
+		Rat   *big.Rat   `morph:"precision=1 half_even"`
+		Int   big.Int    `morph:"precision=-3 half_up"`
+	}
+
+	data := testData{
+		Float: big.NewFloat(1.005),
+		Rat:   big.NewRat(1, 4),
+	}
+	data.Int.SetInt64(123500)
+
+	transformer := New()
+	err := transformer.Struct(&data)
+
+	require.Nil(t, err)
+	require.Equal(t, "1.01", data.Float.Text('f', 2))
+	require.Equal(t, "1/5", data.Rat.RatString())
+	require.Equal(t, "124000", data.Int.String())
+}
+
+func Test_PrecisionInvalidMode(t *testing.T) {
+	type testData struct {
+		Num float64 `morph:"precision=2 baba"`
+	}
+
+	transformer := New()
+	err := transformer.Struct(&testData{})
+
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid parameters")
+	require.Contains(t, err.Error(), "baba")
+}
+
+func Test_PrecisionTooManyPlaces(t *testing.T) {
+	type testData struct {
+		Num float64 `morph:"precision=999999999 half_up"`
+	}
+
+	transformer := New()
+	err := transformer.Struct(&testData{})
+
+	require.Error(t, err)
+	require.Equal(t, "invalid parameters '999999999 half_up' for tag: 'precision'", err.Error())
+}
+
+func Test_RoundIntegersAndComplex(t *testing.T) {
+	type testData struct {
+		Int     int64      `morph:"round"`
+		Uint    uint32     `morph:"ceil"`
+		Complex complex128 `morph:"floor"`
+	}
+
+	data := testData{
+		Int:     15,
+		Uint:    7,
+		Complex: complex(1.5, -1.5),
+	}
+
+	transformer := New()
+	err := transformer.Struct(&data)
+
+	require.Nil(t, err)
+	require.Equal(t, int64(15), data.Int)
+	require.Equal(t, uint32(7), data.Uint)
+	require.Equal(t, complex(1, -2), data.Complex)
+}
+
+func Test_MinMax(t *testing.T) {
+	type testData struct {
+		Ints   []int     `morph:"dive,min=0"`
+		Uints  []uint8   `morph:"dive,max=100"`
+		Floats []float32 `morph:"dive,min=-1.5,max=1.5"`
+	}
+
+	data := testData{
+		Ints:   []int{-5, 0, 5},
+		Uints:  []uint8{50, 100, 150},
+		Floats: []float32{-2, 1, 2},
+	}
+
+	transformer := New()
+	err := transformer.Struct(&data)
+
+	require.Nil(t, err)
+	require.Equal(t, []int{0, 0, 5}, data.Ints)
+	require.Equal(t, []uint8{50, 100, 100}, data.Uints)
+	require.Equal(t, []float32{-1.5, 1, 1.5}, data.Floats)
+}
+
+func Test_MinOverflow(t *testing.T) {
+	type testData struct {
+		Int int8 `morph:"min=300"`
+	}
+
+	transformer := New()
+	err := transformer.Struct(&testData{})
+
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "overflows int8")
+}
+
+func Test_MinFractionOnInteger(t *testing.T) {
+	type testData struct {
+		Int int `morph:"min=1.5"`
+	}
+
+	transformer := New()
+	err := transformer.Struct(&testData{})
+
+	require.Error(t, err)
+	require.Equal(t, "invalid parameters '1.5' for tag: 'min'", err.Error())
+}
+
+func Test_ClampFractionOnInteger(t *testing.T) {
+	type testData struct {
+		Int int `morph:"clamp=0 2.50"`
+	}
+
+	transformer := New()
+	err := transformer.Struct(&testData{})
+
+	require.Error(t, err)
+	require.Equal(t, "invalid parameters '0 2.50' for tag: 'clamp'", err.Error())
+}
+
+func Test_Clamp(t *testing.T) {
+	type testData struct {
+		Cents  []int64   `morph:"dive,clamp=-100 100"`
+		Floats []float64 `morph:"dive,clamp=0 1"`
+	}
+
+	data := testData{
+		Cents:  []int64{-150, 50, 150},
+		Floats: []float64{-0.5, 0.5, 1.5},
+	}
+
+	transformer := New()
+	err := transformer.Struct(&data)
+
+	require.Nil(t, err)
+	require.Equal(t, []int64{-100, 50, 100}, data.Cents)
+	require.Equal(t, []float64{0, 0.5, 1}, data.Floats)
+}
+
+func Test_ClampBadParameters(t *testing.T) {
+	type testData struct {
+		Int int `morph:"clamp=10 1"`
+	}
+
+	type otherData struct {
+		Int int `morph:"clamp=10"`
+	}
+
+	transformer := New()
+
+	err := transformer.Struct(&testData{})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid parameters")
+
+	err = transformer.Struct(&otherData{})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid parameters")
+}
+
+func Test_Abs(t *testing.T) {
+	type testData struct {
+		Int   int     `morph:"abs"`
+		Uint  uint    `morph:"abs"`
+		Float float64 `morph:"abs"`
+	}
+
+	data := testData{
+		Int:   -5,
+		Uint:  5,
+		Float: -1.5,
+	}
+
+	transformer := New()
+	err := transformer.Struct(&data)
+
+	require.Nil(t, err)
+	require.Equal(t, 5, data.Int)
+	require.Equal(t, uint(5), data.Uint)
+	require.Equal(t, 1.5, data.Float)
+}
+
+func Test_AbsOverflow(t *testing.T) {
+	type testData struct {
+		Int int8 `morph:"abs"`
+	}
+
+	data := testData{
+		Int: -128,
+	}
+
+	transformer := New()
+	err := transformer.Struct(&data)
+
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "overflows")
+	require.Equal(t, int8(-128), data.Int)
+}
+
+func Test_AbsComplex(t *testing.T) {
+	type testData struct {
+		Complex complex64 `morph:"abs"`
+	}
+
+	transformer := New()
+	err := transformer.Struct(&testData{})
+
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "unexpected value")
+}
+
+func Test_Multiple(t *testing.T) {
+	type testData struct {
+		Ints   []int     `morph:"dive,multiple=5"`
+		Uints  []uint16  `morph:"dive,multiple=5"`
+		Floats []float64 `morph:"dive,multiple=0.25"`
+		Tenths []float64 `morph:"dive,multiple=0.1"`
+		Single float32   `morph:"multiple=0.05"`
+	}
+
+	data := testData{
+		Ints:   []int{12, 13, -12, -13},
+		Uints:  []uint16{2, 3},
+		Floats: []float64{1.1, 1.2},
+		Tenths: []float64{0.31, -0.25, 0.7},
+		Single: 1.234,
+	}
+
+	transformer := New()
+	err := transformer.Struct(&data)
+
+	require.Nil(t, err)
+	require.Equal(t, []int{10, 15, -10, -15}, data.Ints)
+	require.Equal(t, []uint16{0, 5}, data.Uints)
+	require.Equal(t, []float64{1, 1.25}, data.Floats)
+	require.Equal(t, []float64{0.3, -0.3, 0.7}, data.Tenths)
+	require.Equal(t, float32(1.25), data.Single)
+}
+
+func Test_MultipleOverflow(t *testing.T) {
+	type testData struct {
+		Uint uint8 `morph:"multiple=10"`
+	}
+
+	data := testData{
+		Uint: 255,
+	}
+
+	transformer := New()
+	err := transformer.Struct(&data)
+
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "overflows uint8")
+}
+
+func Test_MultipleNotPositive(t *testing.T) {
+	type testData struct {
+		Int int `morph:"multiple=0"`
+	}
+
+	transformer := New()
+	err := transformer.Struct(&testData{})
+
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid parameters")
+}
+
+func Test_RoundTo(t *testing.T) {
+	type testData struct {
+		Hundreds int64      `morph:"roundto=-2"`
+		Uint     uint       `morph:"roundto=-1"`
+		Float    float64    `morph:"roundto=1"`
+		Tens     float32    `morph:"roundto=-1"`
+		Integer  int        `morph:"roundto=2"`
+		Complex  complex128 `morph:"roundto=-1"`
+	}
+
+	data := testData{
+		Hundreds: 1250,
+		Uint:     14,
+		Float:    1.26,
+		Tens:     -15,
+		Integer:  7,
+		Complex:  complex(14, 16),
+	}
+
+	transformer := New()
+	err := transformer.Struct(&data)
+
+	require.Nil(t, err)
+	require.Equal(t, int64(1300), data.Hundreds)
+	require.Equal(t, uint(10), data.Uint)
+	require.Equal(t, 1.3, data.Float)
+	require.Equal(t, float32(-20), data.Tens)
+	require.Equal(t, 7, data.Integer)
+	require.Equal(t, complex(10, 20), data.Complex)
+}
+
+func Test_RoundToInvalidParameter(t *testing.T) {
+	tests := []struct {
+		name   string
+		data   interface{}
+		errMsg string
+	}{
+		{"not a number", &struct {
+			Float float64 `morph:"roundto=x"`
+		}{}, "invalid parameters 'x' for tag: 'roundto'"},
+		{"too many places", &struct {
+			Float float64 `morph:"roundto=999999999"`
+		}{}, "invalid parameters '999999999' for tag: 'roundto'"},
+		{"too many negative places", &struct {
+			Int int `morph:"roundto=-1001"`
+		}{}, "invalid parameters '-1001' for tag: 'roundto'"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := New().Struct(test.data)
+
+			require.Error(t, err)
+			require.Equal(t, test.errMsg, err.Error())
+		})
+	}
+}
+
+//endregion numbers
+
+//region cross-field
+
+func Test_StructWithTagTrimTruncate(t *testing.T) {
+	type testData struct {
+		String string `morph:"trim,truncate=3"`
+	}
+
+	data := testData{
+		String: " data ",
+	}
+
+	transformer := New()
+	err := transformer.Struct(&data)
+
+	require.Nil(t, err)
+	require.Equal(t, "dat", data.String)
+}
+
+func Test_From(t *testing.T) {
+	type testData struct {
+		Slug  string `morph:"from=Title,lower"`
+		Title string `morph:"trim"`
+	}
+
+	data := testData{
+		Title: " Some Title ",
+	}
+
+	transformer := New()
+	err := transformer.Struct(&data)
+
+	require.Nil(t, err)
+	require.Equal(t, "Some Title", data.Title)
+	require.Equal(t, "some title", data.Slug)
+}
+
+func Test_FromPointer(t *testing.T) {
+	type testData struct {
+		Copy  string `morph:"from=Value"`
+		Value *string
+	}
+
+	value := "value"
+	data := testData{
+		Value: &value,
+	}
+
+	transformer := New()
+	require.Nil(t, transformer.Struct(&data))
+	require.Equal(t, "value", data.Copy)
+
+	data = testData{Copy: "old"}
+	require.Nil(t, transformer.Struct(&data))
+	require.Equal(t, "", data.Copy)
+}
+
+func Test_DefaultFrom(t *testing.T) {
+	type testData struct {
+		DisplayName string `morph:"trim,default_from=Email"`
+		Email       string `morph:"trim,lower"`
+	}
+
+	data := testData{
+		DisplayName: "   ",
+		Email:       " John@Example.com ",
+	}
+
+	transformer := New()
+	err := transformer.Struct(&data)
+
+	require.Nil(t, err)
+	require.Equal(t, "john@example.com", data.DisplayName)
+
+	data = testData{
+		DisplayName: "John",
+		Email:       "john@example.com",
+	}
+
+	err = transformer.Struct(&data)
+
+	require.Nil(t, err)
+	require.Equal(t, "John", data.DisplayName)
+}
+
+func Test_FromParts(t *testing.T) {
+	type testData struct {
+		DisplayName string  `morph:"trim,default_from=Email localpart"`
+		Domain      string  `morph:"from=Email domain"`
+		Local       *string `morph:"from=Email localpart"`
+		Email       string  `morph:"trim,lower"`
+	}
+
+	local := ""
+	data := testData{
+		Email: " John.Doe@Example.com ",
+		Local: &local,
+	}
+
+	transformer := New()
+	err := transformer.Struct(&data)
+
+	require.Nil(t, err)
+	require.Equal(t, "john.doe", data.DisplayName)
+	require.Equal(t, "example.com", data.Domain)
+	require.Equal(t, "john.doe", *data.Local)
+
+	data = testData{
+		DisplayName: "John",
+		Email:       "john",
+	}
+
+	err = transformer.Struct(&data)
+
+	require.Nil(t, err)
+	require.Equal(t, "John", data.DisplayName)
+	require.Equal(t, "", data.Domain)
+}
+
+func Test_FromPartsInvalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		data   interface{}
+		errMsg string
+	}{
+		{"unknown part", &struct {
+			Name  string `morph:"default_from=Email baba"`
+			Email string
+		}{}, "invalid parameters 'Email baba' for tag: 'default_from'"},
+		{"too many parts", &struct {
+			Name  string `morph:"from=Email localpart domain"`
+			Email string
+		}{}, "invalid parameters 'Email localpart domain' for tag: 'from'"},
+		{"not a string", &struct {
+			Name string `morph:"from=Age localpart"`
+			Age  int
+		}{}, "field 'Age' cannot be used as a source for field 'Name'"},
+		{"interface number", &struct {
+			Name  string `morph:"from=Value domain"`
+			Value interface{}
+		}{Value: 5}, "field 'Value' cannot be used as a source for field 'Name'"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := New().Struct(test.data)
+
+			require.Error(t, err)
+			require.Equal(t, test.errMsg, err.Error())
+		})
+	}
+}
+
+func Test_Concat(t *testing.T) {
+	type testData struct {
+		SearchText string `morph:"concat=First Middle Last,lower"`
+		First      string `morph:"trim"`
+		Middle     *string
+		Last       string `morph:"trim"`
+	}
+
+	data := testData{
+		First: " John ",
+		Last:  " Doe ",
+	}
+
+	transformer := New()
+	err := transformer.Struct(&data)
+
+	require.Nil(t, err)
+	require.Equal(t, "john doe", data.SearchText)
+}
+
+func Test_ConcatNotAString(t *testing.T) {
+	type testData struct {
+		SearchText string `morph:"concat=First Age"`
+		First      string
+		Age        int
+	}
+
+	data := testData{}
+
+	transformer := New()
+	err := transformer.Struct(&data)
+
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Age")
+}
+
+func Test_FromChainedDependencies(t *testing.T) {
+	type testData struct {
+		Third  string `morph:"from=Second,upper"`
+		Second string `morph:"from=First,trim"`
+		First  string `morph:"lower"`
+	}
+
+	data := testData{
+		First: " VALUE ",
+	}
+
+	transformer := New()
+	err := transformer.Struct(&data)
+
+	require.Nil(t, err)
+	require.Equal(t, " value ", data.First)
+	require.Equal(t, "value", data.Second)
+	require.Equal(t, "VALUE", data.Third)
+}
+
+func Test_FromCyclicDependency(t *testing.T) {
+	type testData struct {
+		First  string `morph:"from=Second"`
+		Second string `morph:"from=Third"`
+		Third  string `morph:"default_from=First"`
+	}
+
+	data := testData{}
+
+	transformer := New()
+	err := transformer.Struct(&data)
+
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "cyclic")
+	require.Contains(t, err.Error(), "First -> Second -> Third -> First")
+}
+
+func Test_FromUnknownField(t *testing.T) {
+	type testData struct {
+		First  string `morph:"from=Baba"`
+		second string
+	}
+
+	data := testData{}
+
+	transformer := New()
+	err := transformer.Struct(&data)
+
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "unknown field")
+	require.Contains(t, err.Error(), "Baba")
+
+	type otherData struct {
+		First  string `morph:"from=second"`
+		second string
+	}
+
+	err = transformer.Struct(&otherData{})
+
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "unknown field")
+}
+
+func Test_FromMissingParameter(t *testing.T) {
+	type testData struct {
+		First string `morph:"from"`
+	}
+
+	transformer := New()
+	err := transformer.Struct(&testData{})
+
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "missing parameters")
+}
+
+func Test_FromIncompatibleField(t *testing.T) {
+	type testData struct {
+		First  string `morph:"from=Second"`
+		Second int
+	}
+
+	transformer := New()
+	err := transformer.Struct(&testData{})
+
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "cannot be used as a source")
+}
+
+func Test_FromInterfaceSource(t *testing.T) {
+	type testData struct {
+		Source interface{}
+		Target string `morph:"from=Source"`
+		Number int64  `morph:"from=Source"`
+	}
+
+	data := testData{Source: int32(5)}
+
+	transformer := New()
+	err := transformer.Struct(&data)
+
+	require.Error(t, err)
+	require.Equal(t, "field 'Source' cannot be used as a source for field 'Target'", err.Error())
+	require.Equal(t, "", data.Target)
+
+	data = testData{Source: "value"}
+	err = transformer.Struct(&data)
+
+	require.Error(t, err)
+	require.Equal(t, "field 'Source' cannot be used as a source for field 'Number'", err.Error())
+	require.Equal(t, "value", data.Target)
+}
+
+func Test_FromInterfaceNumberSource(t *testing.T) {
+	type testData struct {
+		Source interface{}
+		Target int64 `morph:"from=Source"`
+	}
+
+	data := testData{Source: int32(5)}
+
+	transformer := New()
+	err := transformer.Struct(&data)
+
+	require.Nil(t, err)
+	require.Equal(t, int64(5), data.Target)
+}
+
+func Test_FromInsideDive(t *testing.T) {
+	type testData struct {
+		Items []string `morph:"dive,from=Name"`
+		Name  string
 	}
 
 	transformer := New()
-	err := transformer.Struct(&data)
+	err := transformer.Struct(&testData{})
 
 	require.Error(t, err)
-	require.Contains(t, err.Error(), "invalid parameters")
-	require.Contains(t, err.Error(), "baba")
+	require.Contains(t, err.Error(), "cannot be used inside dive")
 }
 
-func Test_StructWithTagTruncateNegativeParameter(t *testing.T) {
+//endregion cross-field
+
+//region default
+
+func Test_Default(t *testing.T) {
 	type testData struct {
-		String string `morph:"truncate=-1"`
+		String   string        `morph:"default=unknown"`
+		Int      int           `morph:"default=10"`
+		Int8     int8          `morph:"default=-8"`
+		Uint     uint32        `morph:"default=32"`
+		Float    float64       `morph:"default=1.5"`
+		Bool     bool          `morph:"default=true"`
+		Duration time.Duration `morph:"default=1h"`
+		Time     time.Time     `morph:"default=2022-01-02T03:04:05Z"`
+		Now      time.Time     `morph:"default=now"`
 	}
 
-	data := testData{
-		String: "123456",
-	}
+	data := testData{}
 
 	transformer := New()
 	err := transformer.Struct(&data)
 
-	require.Error(t, err)
-	require.Contains(t, err.Error(), "invalid parameters")
+	require.Nil(t, err)
+	require.Equal(t, "unknown", data.String)
+	require.Equal(t, 10, data.Int)
+	require.Equal(t, int8(-8), data.Int8)
+	require.Equal(t, uint32(32), data.Uint)
+	require.Equal(t, 1.5, data.Float)
+	require.Equal(t, true, data.Bool)
+	require.Equal(t, time.Hour, data.Duration)
+	require.Equal(t, time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC), data.Time)
+	require.False(t, data.Now.IsZero())
 }
 
-func Test_StructWithTagTruncate(t *testing.T) {
+func Test_DefaultNotZero(t *testing.T) {
 	type testData struct {
-		String string `morph:"truncate=5"`
+		String string `morph:"trim,default=unknown"`
+		Int    int    `morph:"default=10"`
 	}
+
 	data := testData{
-		String: "123456",
+		String: " value ",
+		Int:    5,
 	}
 
 	transformer := New()
 	err := transformer.Struct(&data)
 
 	require.Nil(t, err)
-	require.Equal(t, "12345", data.String)
+	require.Equal(t, "value", data.String)
+	require.Equal(t, 5, data.Int)
 }
 
-func Test_StructWithTagTruncateExact(t *testing.T) {
+func Test_DefaultAfterTrim(t *testing.T) {
 	type testData struct {
-		String string `morph:"truncate=6"`
+		String string `morph:"trim,default=unknown"`
 	}
+
 	data := testData{
-		String: "123456",
+		String: "   ",
 	}
 
 	transformer := New()
 	err := transformer.Struct(&data)
 
 	require.Nil(t, err)
-	require.Equal(t, "123456", data.String)
+	require.Equal(t, "unknown", data.String)
 }
 
-func Test_StructWithTagTruncateOneMoreThanExact(t *testing.T) {
+func Test_DefaultPointers(t *testing.T) {
 	type testData struct {
-		String string `morph:"truncate=7"`
-	}
-	data := testData{
-		String: "123456",
+		String *string    `morph:"default=unknown"`
+		Bool   *bool      `morph:"default=false"`
+		Time   *time.Time `morph:"default=now"`
+		Other  *string    `morph:"trim"`
 	}
 
+	data := testData{}
+
 	transformer := New()
 	err := transformer.Struct(&data)
 
 	require.Nil(t, err)
-	require.Equal(t, "123456", data.String)
+	require.NotNil(t, data.String)
+	require.Equal(t, "unknown", *data.String)
+	require.NotNil(t, data.Bool)
+	require.False(t, *data.Bool)
+	require.NotNil(t, data.Time)
+	require.False(t, data.Time.IsZero())
+	require.Nil(t, data.Other)
 }
 
-//endregion truncate
-
-//region mixed
-
-func Test_StructWithTagTrimLower_ArrayOfStrings(t *testing.T) {
+func Test_DefaultNamedTypes(t *testing.T) {
+	type timeout time.Duration
+	type stamp time.Time
 	type testData struct {
-		OtherData []string `morph:"dive,trim,lower"`
+		Timeout timeout `morph:"default=1m"`
+		Stamp   stamp   `morph:"default=2022-01-02"`
 	}
 
-	data := testData{
-		OtherData: []string{" DATA ", " DATA2 "},
-	}
+	data := testData{}
 
 	transformer := New()
+	require.Nil(t, transformer.RegisterLeafType(timeout(0)))
 	err := transformer.Struct(&data)
 
 	require.Nil(t, err)
-	require.Equal(t, "data", data.OtherData[0])
-	require.Equal(t, "data2", data.OtherData[1])
+	require.Equal(t, timeout(time.Minute), data.Timeout)
+	require.Equal(t, stamp(time.Date(2022, 1, 2, 0, 0, 0, 0, time.UTC)), data.Stamp)
 }
 
-func Test_StructWithTagTrimLower_ArrayOfArrayOfStringsDoubleDive(t *testing.T) {
+func Test_NilPointersWithoutAllocator(t *testing.T) {
 	type testData struct {
-		OtherData [][]string `morph:"dive,dive,trim,lower"`
+		Min      *int           `morph:"min=5"`
+		Duration *time.Duration `morph:"dmin=1s"`
+		Trimmed  *string        `morph:"trim"`
+		Default  *int           `morph:"default=1,min=5"`
 	}
 
-	data := testData{
-		OtherData: [][]string{{" DATA ", " DATA2 "}},
-	}
+	data := testData{}
 
 	transformer := New()
 	err := transformer.Struct(&data)
 
 	require.Nil(t, err)
-	require.Equal(t, "data", data.OtherData[0][0])
-	require.Equal(t, "data2", data.OtherData[0][1])
+	require.Nil(t, data.Min)
+	require.Nil(t, data.Duration)
+	require.Nil(t, data.Trimmed)
+	require.NotNil(t, data.Default)
+	require.Equal(t, 5, *data.Default)
 }
 
-func Test_Struct_MapOfStringsKeysMultipleTransforms(t *testing.T) {
+func Test_PointerTransformedToZero(t *testing.T) {
 	type testData struct {
-		Map map[string]string `morph:"dive,keys,trim,lower,exit,trim,lower"`
+		String *string `morph:"trim"`
 	}
 
+	blank := "   "
 	data := testData{
-		Map: map[string]string{
-			" KEY1 ": " VALUE1 ",
-			" KEY2 ": " VALUE2 ",
-		},
+		String: &blank,
 	}
 
 	transformer := New()
 	err := transformer.Struct(&data)
 
 	require.Nil(t, err)
-	require.Equal(t, "value1", data.Map["key1"])
-	require.Equal(t, "value2", data.Map["key2"])
+	require.NotNil(t, data.String)
+	require.Equal(t, "", *data.String)
 }
 
-//endregion mixed
-
-//region numbers
-
-func Test_Ceil(t *testing.T) {
+func Test_DefaultDive(t *testing.T) {
 	type testData struct {
-		Numbers64 []float64 `morph:"dive,ceil"`
-		Numbers32 []float32 `morph:"dive,ceil"`
+		Strings []string        `morph:"dive,default=empty"`
+		Map     map[string]*int `morph:"dive,default=7"`
 	}
 
 	data := testData{
-		Numbers64: []float64{
-			1.465,
-			-1.465,
-			1,
-		},
-		Numbers32: []float32{
-			1.465,
-			-1.465,
-			1,
-		},
+		Strings: []string{"", "value"},
+		Map:     map[string]*int{"key": nil},
 	}
 
 	transformer := New()
 	err := transformer.Struct(&data)
 
 	require.Nil(t, err)
+	require.Equal(t, []string{"empty", "value"}, data.Strings)
+	require.Equal(t, 7, *data.Map["key"])
+}
 
-	require.Equal(t, float64(2), data.Numbers64[0])
-	require.Equal(t, float64(-1), data.Numbers64[1])
-	require.Equal(t, float64(1), data.Numbers64[2])
+func Test_DefaultMissingParameter(t *testing.T) {
+	type testData struct {
+		String string `morph:"default="`
+	}
 
-	require.Equal(t, float32(2), data.Numbers32[0])
-	require.Equal(t, float32(-1), data.Numbers32[1])
-	require.Equal(t, float32(1), data.Numbers32[2])
+	transformer := New()
+	err := transformer.Struct(&testData{})
+
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "missing parameters")
 }
 
-func Test_Floor(t *testing.T) {
+func Test_DefaultInvalidParameter(t *testing.T) {
 	type testData struct {
-		Numbers64 []float64 `morph:"dive,floor"`
-		Numbers32 []float32 `morph:"dive,floor"`
+		Int8 int8 `morph:"default=300"`
 	}
 
-	data := testData{
-		Numbers64: []float64{
-			1.565,
-			-1.465,
-			1,
-		},
-		Numbers32: []float32{
-			1.465,
-			-1.465,
-			1,
-		},
+	transformer := New()
+	err := transformer.Struct(&testData{})
+
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid parameters")
+	require.Contains(t, err.Error(), "300")
+}
+
+func Test_DefaultUnsupportedKind(t *testing.T) {
+	type testData struct {
+		Items []string `morph:"default=value"`
 	}
 
 	transformer := New()
-	err := transformer.Struct(&data)
+	err := transformer.Struct(&testData{})
 
-	require.Nil(t, err)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "unexpected value")
+}
 
-	require.Equal(t, float64(1), data.Numbers64[0])
-	require.Equal(t, float64(-2), data.Numbers64[1])
-	require.Equal(t, float64(1), data.Numbers64[2])
+//endregion default
 
-	require.Equal(t, float32(1), data.Numbers32[0])
-	require.Equal(t, float32(-2), data.Numbers32[1])
-	require.Equal(t, float32(1), data.Numbers32[2])
-}
+//region time
 
-func Test_Round(t *testing.T) {
+func Test_StructWithTimeTags(t *testing.T) {
 	type testData struct {
-		Numbers64 []float64 `morph:"dive,round"`
-		Numbers32 []float32 `morph:"dive,round"`
-	}
+		UTC        time.Time   `morph:"utc"`
+		Zone       time.Time   `morph:"tz=Europe/Sofia"`
+		Truncated  time.Time   `morph:"truncate_time=1h"`
+		StartOfDay time.Time   `morph:"startofday"`
+		NotAfter   time.Time   `morph:"notafter=2022-01-01"`
+		Before     time.Time   `morph:"notafter=2022-01-01"`
+		Pointer    *time.Time  `morph:"utc,startofday"`
+		Nil        *time.Time  `morph:"utc"`
+		Times      []time.Time `morph:"dive,truncate_time=1m"`
+	}
+
+	sofia, err := time.LoadLocation("Europe/Sofia")
+	require.Nil(t, err)
 
+	local := time.Date(2022, 6, 15, 1, 45, 30, 500, sofia)
+	pointer := local
 	data := testData{
-		Numbers64: []float64{
-			1.565,
-			1.456,
-			-1.565,
-			-1.465,
-			1,
-		},
-		Numbers32: []float32{
-			1.565,
-			1.465,
-			-1.565,
-			-1.465,
-			1,
-		},
+		UTC:        local,
+		Zone:       time.Date(2022, 1, 1, 10, 0, 0, 0, time.UTC),
+		Truncated:  time.Date(2022, 1, 1, 10, 45, 30, 0, time.UTC),
+		StartOfDay: local,
+		NotAfter:   time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC),
+		Before:     time.Date(2021, 5, 1, 0, 0, 0, 0, time.UTC),
+		Pointer:    &pointer,
+		Times:      []time.Time{time.Date(2022, 1, 1, 10, 45, 30, 0, time.UTC)},
 	}
 
 	transformer := New()
-	err := transformer.Struct(&data)
+	err = transformer.Struct(&data)
 
 	require.Nil(t, err)
-
-	require.Equal(t, float64(2), data.Numbers64[0])
-	require.Equal(t, float64(1), data.Numbers64[1])
-	require.Equal(t, float64(-2), data.Numbers64[2])
-	require.Equal(t, float64(-1), data.Numbers64[3])
-	require.Equal(t, float64(1), data.Numbers64[4])
-
-	require.Equal(t, float32(2), data.Numbers32[0])
-	require.Equal(t, float32(1), data.Numbers32[1])
-	require.Equal(t, float32(-2), data.Numbers32[2])
-	require.Equal(t, float32(-1), data.Numbers32[3])
-	require.Equal(t, float32(1), data.Numbers32[4])
+	require.Equal(t, time.Date(2022, 6, 14, 22, 45, 30, 500, time.UTC), data.UTC)
+	require.Equal(t, time.UTC, data.UTC.Location())
+	require.Equal(t, "2022-01-01T12:00:00+02:00", data.Zone.Format(time.RFC3339))
+	require.Equal(t, sofia, data.Zone.Location())
+	require.Equal(t, time.Date(2022, 1, 1, 10, 0, 0, 0, time.UTC), data.Truncated)
+	require.Equal(t, time.Date(2022, 6, 15, 0, 0, 0, 0, sofia), data.StartOfDay)
+	require.Equal(t, time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC), data.NotAfter)
+	require.Equal(t, time.Date(2021, 5, 1, 0, 0, 0, 0, time.UTC), data.Before)
+	require.Equal(t, time.Date(2022, 6, 14, 0, 0, 0, 0, time.UTC), *data.Pointer)
+	require.Nil(t, data.Nil)
+	require.Equal(t, []time.Time{time.Date(2022, 1, 1, 10, 45, 0, 0, time.UTC)}, data.Times)
 }
 
-func Test_PrecisionBadParameter(t *testing.T) {
+func Test_StructWithTagNotAfterNow(t *testing.T) {
 	type testData struct {
-		Num float64 `morph:"precision=baba"`
+		Future time.Time `morph:"notafter=now"`
+		Past   time.Time `morph:"notafter=now"`
 	}
 
+	past := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
 	data := testData{
-		Num: 1.16,
+		Future: time.Now().Add(time.Hour),
+		Past:   past,
 	}
 
 	transformer := New()
 	err := transformer.Struct(&data)
 
-	require.Error(t, err)
-	require.Contains(t, err.Error(), "invalid parameters")
-	require.Contains(t, err.Error(), "baba")
+	require.Nil(t, err)
+	require.False(t, data.Future.After(time.Now()))
+	require.True(t, data.Future.After(time.Now().Add(-time.Minute)))
+	require.Equal(t, past, data.Past)
 }
 
-func Test_PrecisionNoParameter(t *testing.T) {
+func Test_StructWithTagStripMonotonic(t *testing.T) {
 	type testData struct {
-		Num float64 `morph:"precision"`
+		Time time.Time `morph:"strip_monotonic"`
 	}
 
-	data := testData{
-		Num: 1.16,
-	}
+	now := time.Now()
+	data := testData{Time: now}
 
 	transformer := New()
 	err := transformer.Struct(&data)
 
-	require.Error(t, err)
-	require.Contains(t, err.Error(), "invalid parameters")
+	require.Nil(t, err)
+	require.True(t, now.Equal(data.Time))
+	require.True(t, data.Time == now.Round(0))
+	require.NotContains(t, data.Time.String(), "m=")
 }
 
-func Test_PrecisionMissingParameter(t *testing.T) {
+func Test_StructWithDurationTags(t *testing.T) {
 	type testData struct {
-		Num float64 `morph:"precision="`
+		Round    time.Duration   `morph:"dround=1s"`
+		Min      time.Duration   `morph:"dmin=1s"`
+		Max      time.Duration   `morph:"dmax=1m"`
+		Clamped  *time.Duration  `morph:"dmin=1s,dmax=1m"`
+		Kept     time.Duration   `morph:"dmin=1s,dmax=1m"`
+		Negative time.Duration   `morph:"dmin=-1s"`
+		Items    []time.Duration `morph:"dive,dround=1ms"`
 	}
 
+	clamped := 2 * time.Hour
 	data := testData{
-		Num: 1.16,
+		Round:    1600 * time.Millisecond,
+		Min:      100 * time.Millisecond,
+		Max:      time.Hour,
+		Clamped:  &clamped,
+		Kept:     30 * time.Second,
+		Negative: -time.Minute,
+		Items:    []time.Duration{1500 * time.Microsecond},
 	}
 
 	transformer := New()
 	err := transformer.Struct(&data)
 
-	require.Error(t, err)
-	require.Contains(t, err.Error(), "invalid parameters")
+	require.Nil(t, err)
+	require.Equal(t, 2*time.Second, data.Round)
+	require.Equal(t, time.Second, data.Min)
+	require.Equal(t, time.Minute, data.Max)
+	require.Equal(t, time.Minute, *data.Clamped)
+	require.Equal(t, 30*time.Second, data.Kept)
+	require.Equal(t, -time.Second, data.Negative)
+	require.Equal(t, []time.Duration{2 * time.Millisecond}, data.Items)
 }
 
-func Test_Precision(t *testing.T) {
+func Test_StructWithTimeTagsNamedTypes(t *testing.T) {
+	type timeout time.Duration
+	type stamp time.Time
 	type testData struct {
-		Num164 float64 `morph:"precision=1"`
-		Num132 float32 `morph:"precision=1"`
-		Num264 float64 `morph:"precision=2"`
-		Num232 float32 `morph:"precision=2"`
-		Num364 float64 `morph:"precision=0"`
-		Num332 float32 `morph:"precision=0"`
+		Timeout  timeout   `morph:"dround=1s"`
+		Timeouts []timeout `morph:"dive,dmax=1m"`
+		Stamp    stamp     `morph:"utc,startofday"`
+		Pointer  *stamp    `morph:"truncate_time=1h"`
 	}
 
+	zone := time.FixedZone("UTC+2", 2*60*60)
+	pointer := stamp(time.Date(2022, 3, 4, 5, 6, 7, 0, time.UTC))
 	data := testData{
-		Num164: 1.16,
-		Num132: 1.16,
-		Num264: 1.167,
-		Num232: 1.167,
-		Num364: 1.9,
-		Num332: 1.9,
+		Timeout:  timeout(1600 * time.Millisecond),
+		Timeouts: []timeout{timeout(time.Hour), timeout(time.Second)},
+		Stamp:    stamp(time.Date(2022, 3, 4, 1, 6, 7, 0, zone)),
+		Pointer:  &pointer,
 	}
 
 	transformer := New()
+	require.Nil(t, transformer.RegisterLeafType(timeout(0)))
 	err := transformer.Struct(&data)
 
 	require.Nil(t, err)
-
-	require.Equal(t, 1.1, data.Num164)
-	require.Equal(t, 1.16, data.Num264)
-	require.Equal(t, float64(1), data.Num364)
-	require.Equal(t, float32(1.1), data.Num132)
-	require.Equal(t, float32(1.16), data.Num232)
-	require.Equal(t, float32(1), data.Num332)
+	require.Equal(t, timeout(2*time.Second), data.Timeout)
+	require.Equal(t, []timeout{timeout(time.Minute), timeout(time.Second)}, data.Timeouts)
+	require.Equal(t, time.Date(2022, 3, 3, 0, 0, 0, 0, time.UTC), time.Time(data.Stamp))
+	require.Equal(t, time.Date(2022, 3, 4, 5, 0, 0, 0, time.UTC), time.Time(*data.Pointer))
 }
 
-func Test_PrecisionOnZeroValue(t *testing.T) {
-	type testData struct {
-		Num1 float64 `morph:"precision=1"`
-		Num2 float32 `morph:"precision=1"`
+func Test_StructWithDurationTagsUnregisteredInt64(t *testing.T) {
+	type userID int64
+	type durationData struct {
+		ID userID `morph:"dmin=1s"`
 	}
 
-	data := testData{
-		Num1: 0.0,
-		Num2: 0.0,
+	type defaultData struct {
+		ID userID `morph:"default=1s"`
 	}
 
 	transformer := New()
+	data := durationData{ID: 5}
 	err := transformer.Struct(&data)
 
-	require.Nil(t, err)
+	require.Error(t, err)
+	require.Equal(t, "unexpected value:'int64' for tag: 'dmin'", err.Error())
+	require.Equal(t, userID(5), data.ID)
 
-	require.Equal(t, 0.0, data.Num1)
-	require.Equal(t, float32(0.0), data.Num2)
+	err = transformer.Struct(&defaultData{})
+
+	require.Error(t, err)
+	require.Equal(t, "invalid parameters '1s' for tag: 'default'", err.Error())
 }
 
-//endregion numbers
+func Test_StructWithTimeTagsInvalidParameters(t *testing.T) {
+	tests := []struct {
+		name   string
+		data   interface{}
+		errMsg string
+	}{
+		{"unknown zone", &struct {
+			Time time.Time `morph:"tz=Europe/Nowhere"`
+		}{}, "invalid parameters 'Europe/Nowhere' for tag: 'tz'"},
+		{"missing zone", &struct {
+			Time time.Time `morph:"tz"`
+		}{}, "missing parameters for tag: tz"},
+		{"zero truncation", &struct {
+			Time time.Time `morph:"truncate_time=0s"`
+		}{}, "invalid parameters '0s' for tag: 'truncate_time'"},
+		{"invalid bound", &struct {
+			Time time.Time `morph:"notafter=tomorrow"`
+		}{}, "invalid parameters 'tomorrow' for tag: 'notafter'"},
+		{"negative rounding", &struct {
+			Duration time.Duration `morph:"dround=-1s"`
+		}{}, "invalid parameters '-1s' for tag: 'dround'"},
+		{"invalid limit", &struct {
+			Duration time.Duration `morph:"dmax=1 minute"`
+		}{}, "invalid parameters '1 minute' for tag: 'dmax'"},
+		{"missing limit", &struct {
+			Duration time.Duration `morph:"dmin="`
+		}{}, "missing parameters for tag: dmin"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := New().Struct(test.data)
+
+			require.Error(t, err)
+			require.Equal(t, test.errMsg, err.Error())
+		})
+	}
+}
+
+func Test_StructWithTimeTagsUnexpectedValue(t *testing.T) {
+	tests := []struct {
+		name   string
+		data   interface{}
+		errMsg string
+	}{
+		{"string", &struct {
+			String string `morph:"utc"`
+		}{}, "unexpected value:'string' for tag: 'utc'"},
+		{"int64", &struct {
+			Int int64 `morph:"dmin=1s"`
+		}{}, "unexpected value:'int64' for tag: 'dmin'"},
+		{"duration", &struct {
+			Duration time.Duration `morph:"startofday"`
+		}{}, "unexpected value:'int64' for tag: 'startofday'"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := New().Struct(test.data)
+
+			require.Error(t, err)
+			require.Equal(t, test.errMsg, err.Error())
+		})
+	}
+}
+
+//endregion time
 
 //endregion Struct
 
@@ -1286,6 +5605,14 @@ func Test_RegisterExitKeysOverride(t *testing.T) {
 	require.Contains(t, err.Error(), "reserved tag")
 }
 
+func Test_RegisterCrossFieldOverride(t *testing.T) {
+	transformer := New()
+	err := transformer.Register("from", new(emptyTransformer))
+
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "reserved tag")
+}
+
 func Test_RegisterIgnoreOverride(t *testing.T) {
 	transformer := New()
 	err := transformer.Register("-", new(emptyTransformer))
@@ -1316,6 +5643,53 @@ func Test_StructWithCustomTag(t *testing.T) {
 	require.Equal(t, "baba", data.String)
 }
 
+func Test_RegisterLeafType(t *testing.T) {
+	type money struct {
+		Amount   int64
+		Currency string `morph:"upper"`
+	}
+
+	type testData struct {
+		Price   money  `morph:"cents"`
+		Pointer *money `morph:"cents"`
+		Nested  money
+	}
+
+	data := testData{
+		Price:   money{Amount: 12, Currency: "eur"},
+		Pointer: &money{Amount: 3, Currency: "usd"},
+		Nested:  money{Amount: 5, Currency: "bgn"},
+	}
+
+	transformer := New()
+	require.Nil(t, transformer.RegisterLeafType(&money{}))
+	require.Nil(t, transformer.Register("cents", &funcTransformer{
+		Func: func(value *reflect.Value, _ *string) error {
+			price := value.Interface().(money)
+			price.Amount *= 100
+			value.Set(reflect.ValueOf(price))
+			return nil
+		},
+	}))
+	err := transformer.Struct(&data)
+
+	require.Nil(t, err)
+	require.Equal(t, money{Amount: 1200, Currency: "eur"}, data.Price)
+	require.Equal(t, money{Amount: 300, Currency: "usd"}, *data.Pointer)
+	require.Equal(t, money{Amount: 5, Currency: "bgn"}, data.Nested)
+}
+
+func Test_RegisterLeafTypeInvalid(t *testing.T) {
+	transformer := New()
+
+	for _, value := range []interface{}{nil, "value", new(int), []time.Time{}} {
+		err := transformer.RegisterLeafType(value)
+
+		require.Error(t, err)
+		require.Equal(t, "invalid leaf type", err.Error())
+	}
+}
+
 //endregion Register
 
 //region WithTag
@@ -1348,6 +5722,36 @@ func Test_WithTag(t *testing.T) {
 	require.Equal(t, "YES", data.SomeString)
 }
 
+func Test_WithTagSharedInstance(t *testing.T) {
+	type testData struct {
+		Name  string `morph:"truncate=4,reverse" log:"truncate=2"`
+		Email string `morph:"trim" log:"maskemail"`
+	}
+
+	transformer := New()
+	logger := transformer.WithTag("log")
+	require.Nil(t, logger.Register("reverse", &funcTransformer{Func: func(value *reflect.Value, _ *string) error {
+		runes := []rune(value.String())
+		for i, j := 0, len(runes)-1; i < j; i, j = i+1, j-1 {
+			runes[i], runes[j] = runes[j], runes[i]
+		}
+		value.SetString(string(runes))
+		return nil
+	}}))
+
+	data := testData{"Johnny", " john@example.com "}
+	require.Nil(t, transformer.Struct(&data))
+	require.Equal(t, testData{"nhoJ", "john@example.com"}, data)
+
+	logged := testData{"Johnny", "john@example.com"}
+	require.Nil(t, logger.Struct(&logged))
+	require.Equal(t, testData{"Jo", "j***@example.com"}, logged)
+
+	data = testData{"Johnny", " john@example.com "}
+	require.Nil(t, transformer.Struct(&data))
+	require.Equal(t, testData{"nhoJ", "john@example.com"}, data)
+}
+
 //endregion WithTag
 
 type emptyTransformer struct {
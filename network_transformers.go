@@ -0,0 +1,249 @@
+/*
+	MIT License
+
+	Copyright (c) 2022 Antony Jekov
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in all
+	copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+	SOFTWARE.
+*/
+
+package morph
+
+import (
+	"net"
+	"reflect"
+	"strings"
+)
+
+//region IP
+
+type ipTransformer struct {
+	ParameterlessTransformer
+}
+
+func (t *ipTransformer) transformsBytes() bool {
+	return true
+}
+
+func (t *ipTransformer) Transform(value *reflect.Value, _ *string) error {
+	if value.Type() == ipType {
+		ip := value.Bytes()
+		if len(ip) == 0 {
+			return nil
+		}
+
+		if len(ip) != net.IPv4len && len(ip) != net.IPv6len {
+			return newErrorf(ErrInvalidValueFmt, net.IP(ip).String(), TagIP)
+		}
+
+		if ipv4 := net.IP(ip).To4(); ipv4 != nil {
+			value.SetBytes(ipv4)
+		}
+
+		return nil
+	}
+
+	if isBytes(value) {
+		return transformBytesAsString(value, func(text *reflect.Value) error {
+			return transformCanonicalString(text, TagIP, canonicalizeIP)
+		})
+	}
+
+	return transformCanonicalString(value, TagIP, canonicalizeIP)
+}
+
+// canonicalizeIP returns the shortest form of an address, where IPv4-mapped IPv6 addresses are written as IPv4
+func canonicalizeIP(address string) (string, bool) {
+	zone := ""
+	if i := strings.IndexByte(address, '%'); i > 0 {
+		address, zone = address[:i], address[i:]
+	}
+
+	ip := net.ParseIP(address)
+	if ip == nil || len(zone) > 0 && (ip.To4() != nil || len(zone) == 1) {
+		return "", false
+	}
+
+	return ip.String() + zone, true
+}
+
+//endregion IP
+
+//region CIDR
+
+type cidrTransformer struct {
+	ParameterlessTransformer
+}
+
+func (t *cidrTransformer) Transform(value *reflect.Value, _ *string) error {
+	if value.Type() == ipNetType {
+		network := value.Addr().Interface().(*net.IPNet)
+		if len(network.IP) == 0 && len(network.Mask) == 0 {
+			return nil
+		}
+
+		canonical, ok := canonicalizeNetwork(network)
+		if !ok {
+			return newErrorf(ErrInvalidValueFmt, network.String(), TagCIDR)
+		}
+
+		value.Set(reflect.ValueOf(*canonical))
+		return nil
+	}
+
+	return transformCanonicalString(value, TagCIDR, func(cidr string) (string, bool) {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return "", false
+		}
+
+		network, ok := canonicalizeNetwork(network)
+		if !ok {
+			return "", false
+		}
+
+		return network.String(), true
+	})
+}
+
+// canonicalizeNetwork clears the host bits of a network and converts IPv4-mapped IPv6 networks to IPv4
+func canonicalizeNetwork(network *net.IPNet) (*net.IPNet, bool) {
+	ones, bits := network.Mask.Size()
+	if bits == 0 {
+		return nil, false
+	}
+
+	ip := network.IP.Mask(network.Mask)
+	if ip == nil {
+		return nil, false
+	}
+
+	if ipv4 := ip.To4(); ipv4 != nil {
+		if bits == 8*net.IPv6len {
+			if ones < 8*(net.IPv6len-net.IPv4len) {
+				return nil, false
+			}
+			ones -= 8 * (net.IPv6len - net.IPv4len)
+		}
+
+		return &net.IPNet{IP: ipv4, Mask: net.CIDRMask(ones, 8*net.IPv4len)}, true
+	}
+
+	return &net.IPNet{IP: ip, Mask: net.CIDRMask(ones, bits)}, true
+}
+
+//endregion CIDR
+
+//region MAC
+
+// macSeparators are the separators accepted between the groups of a hardware address
+const macSeparators = ":-. "
+
+type macTransformer struct {
+	ParameterlessTransformer
+}
+
+func (t *macTransformer) transformsBytes() bool {
+	return true
+}
+
+func (t *macTransformer) Transform(value *reflect.Value, _ *string) error {
+	if value.Type() == hardwareAddrType {
+		switch len(value.Bytes()) {
+		case 0, 6, 8, 20:
+			return nil
+		}
+
+		return newErrorf(ErrInvalidValueFmt, net.HardwareAddr(value.Bytes()).String(), TagMAC)
+	}
+
+	if isBytes(value) {
+		return transformBytesAsString(value, func(text *reflect.Value) error {
+			return transformCanonicalString(text, TagMAC, canonicalizeMAC)
+		})
+	}
+
+	return transformCanonicalString(value, TagMAC, canonicalizeMAC)
+}
+
+// canonicalizeMAC accepts EUI-48, EUI-64 and 20-octet addresses written as octets separated by colons, hyphens,
+// dots or spaces, where the leading zero may be omitted, as groups of equal length or as plain hex digits. All groups
+// have to be separated by the same separator and none of them may be empty.
+func canonicalizeMAC(address string) (string, bool) {
+	groups := []string{address}
+	if i := strings.IndexAny(address, macSeparators); i >= 0 {
+		separator := address[i : i+1]
+		if strings.ContainsAny(strings.ReplaceAll(address, separator, ""), macSeparators) {
+			return "", false
+		}
+
+		groups = strings.Split(address, separator)
+	}
+
+	digits := ""
+	for _, group := range groups {
+		switch {
+		case len(group) == 0:
+			return "", false
+		case len(groups) == 1:
+			digits += group
+		case len(group) <= 2 && len(groups[0]) <= 2:
+			digits += strings.Repeat("0", 2-len(group)) + group
+		case len(group) == len(groups[0]) && len(group)%2 == 0:
+			digits += group
+		default:
+			return "", false
+		}
+	}
+
+	for i := 0; i < len(digits); i++ {
+		if !isHex(digits[i]) {
+			return "", false
+		}
+	}
+
+	switch len(digits) {
+	case 12, 16, 40:
+	default:
+		return "", false
+	}
+
+	digits = strings.ToLower(digits)
+	octets := make([]string, 0, len(digits)/2)
+	for i := 0; i < len(digits); i += 2 {
+		octets = append(octets, digits[i:i+2])
+	}
+
+	return strings.Join(octets, ":"), true
+}
+
+//endregion MAC
+
+//region Hostname
+
+type hostnameTransformer struct {
+	ParameterlessTransformer
+}
+
+func (t *hostnameTransformer) Transform(value *reflect.Value, _ *string) error {
+	return transformCanonicalString(value, TagHostname, func(hostname string) (string, bool) {
+		return toASCIIDomain(strings.TrimSuffix(hostname, "."))
+	})
+}
+
+//endregion Hostname
@@ -0,0 +1,514 @@
+/*
+	MIT License
+
+	Copyright (c) 2022 Antony Jekov
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in all
+	copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+	SOFTWARE.
+*/
+
+package morph
+
+import (
+	"math"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// maxDecimalPlaces limits the decimal places of the rounding tags, as the scale of the numbers grows with them
+const maxDecimalPlaces = 1000
+
+//NumbersParameterTransformer is used to parse numeric params and store them for use in the transformation process
+type NumbersParameterTransformer struct {
+	Values map[string][]*big.Rat
+	Mutex  *sync.RWMutex
+	tag    string
+	count  int
+	params map[string]string
+}
+
+//NewNumbersParamsTransformer returns a new instance expecting the given count of space separated numbers
+func NewNumbersParamsTransformer(mutex *sync.RWMutex, tag string, count int) NumbersParameterTransformer {
+	return NumbersParameterTransformer{
+		make(map[string][]*big.Rat),
+		mutex,
+		tag,
+		count,
+		make(map[string]string),
+	}
+}
+
+func (t *NumbersParameterTransformer) Cache(params, key *string) error {
+	fields := strings.Fields(*params)
+	if len(fields) != t.count {
+		return newErrorf(ErrInvalidParameters, *params, t.tag)
+	}
+
+	values := make([]*big.Rat, len(fields))
+	for i, field := range fields {
+		value, ok := new(big.Rat).SetString(field)
+		if !ok {
+			return newErrorf(ErrInvalidParameters, *params, t.tag)
+		}
+
+		values[i] = value
+	}
+
+	t.Mutex.Lock()
+	t.Values[*key] = values
+	t.params[*key] = *params
+	t.Mutex.Unlock()
+
+	return nil
+}
+
+func (t *NumbersParameterTransformer) get(key *string) ([]*big.Rat, error) {
+	t.Mutex.RLock()
+	values, ok := t.Values[*key]
+	t.Mutex.RUnlock()
+
+	if !ok {
+		return nil, newErrorf(ErrMissingParametersFmt, t.tag)
+	}
+
+	return values, nil
+}
+
+var roundingModes = map[string]bool{
+	RoundHalfUp:   true,
+	RoundHalfEven: true,
+	RoundDown:     true,
+	RoundUp:       true,
+	RoundCeil:     true,
+	RoundFloor:    true,
+}
+
+//region helpers
+
+type integerOperation func(number *big.Int) (*big.Int, error)
+
+type floatOperation func(number float64) (float64, error)
+
+func keepInteger(number *big.Int) (*big.Int, error) {
+	return number, nil
+}
+
+// transformNumber applies the operation matching the kind of the value and reports results which do not fit in it.
+// Complex numbers have the float operation applied to their real and imaginary parts if componentwise is set.
+func transformNumber(value *reflect.Value, tag string, componentwise bool, integer integerOperation,
+	float floatOperation) error {
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		result, err := integer(big.NewInt(value.Int()))
+		if err != nil {
+			return err
+		}
+
+		if !result.IsInt64() || value.OverflowInt(result.Int64()) {
+			return newErrorf(ErrOverflowFmt, value.Type().String(), tag)
+		}
+
+		value.SetInt(result.Int64())
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		result, err := integer(new(big.Int).SetUint64(value.Uint()))
+		if err != nil {
+			return err
+		}
+
+		if result.Sign() < 0 || !result.IsUint64() || value.OverflowUint(result.Uint64()) {
+			return newErrorf(ErrOverflowFmt, value.Type().String(), tag)
+		}
+
+		value.SetUint(result.Uint64())
+		return nil
+	case reflect.Float32, reflect.Float64:
+		result, err := applyFloat(value, value.Float(), tag, float)
+		if err != nil {
+			return err
+		}
+
+		value.SetFloat(result)
+		return nil
+	case reflect.Complex64, reflect.Complex128:
+		if !componentwise {
+			break
+		}
+
+		number := value.Complex()
+		realPart, err := applyFloat(value, real(number), tag, float)
+		if err != nil {
+			return err
+		}
+
+		imagPart, err := applyFloat(value, imag(number), tag, float)
+		if err != nil {
+			return err
+		}
+
+		value.SetComplex(complex(realPart, imagPart))
+		return nil
+	}
+
+	return newErrorf(ErrUnexpectedValue, value.Type().Kind().String(), tag)
+}
+
+func applyFloat(value *reflect.Value, number float64, tag string, float floatOperation) (float64, error) {
+	result, err := float(number)
+	if err != nil {
+		return 0, err
+	}
+
+	overflows := math.IsInf(result, 0) && !math.IsInf(number, 0)
+	if value.Kind() == reflect.Float32 || value.Kind() == reflect.Complex64 {
+		overflows = overflows || math.Abs(result) > math.MaxFloat32 && !math.IsInf(result, 0)
+	}
+
+	if overflows {
+		return 0, newErrorf(ErrOverflowFmt, value.Type().String(), tag)
+	}
+
+	return result, nil
+}
+
+// toInteger returns a param of an integer field, which is reported with the params as they were written if it has a
+// fraction
+func (t *NumbersParameterTransformer) toInteger(key *string, number *big.Rat) (*big.Int, error) {
+	if !number.IsInt() {
+		t.Mutex.RLock()
+		params := t.params[*key]
+		t.Mutex.RUnlock()
+
+		return nil, newErrorf(ErrInvalidParameters, params, t.tag)
+	}
+
+	return new(big.Int).Set(number.Num()), nil
+}
+
+func toFloat(number *big.Rat) float64 {
+	result, _ := number.Float64()
+	return result
+}
+
+func roundToMultiple(number, multiple *big.Int) *big.Int {
+	quotient, remainder := new(big.Int).QuoRem(number, multiple, new(big.Int))
+	if new(big.Int).Abs(new(big.Int).Lsh(remainder, 1)).Cmp(multiple) >= 0 {
+		quotient.Add(quotient, big.NewInt(int64(number.Sign())))
+	}
+
+	return quotient.Mul(quotient, multiple)
+}
+
+// roundRatToMultiple rounds a number to the nearest multiple, where halves are rounded away from zero
+func roundRatToMultiple(number, multiple *big.Rat) *big.Rat {
+	quotient := new(big.Rat).Quo(number, multiple)
+	rounded := new(big.Rat).SetFrac(roundToMultiple(quotient.Num(), quotient.Denom()), quotient.Denom())
+	return rounded.Mul(rounded, multiple)
+}
+
+// decimalFloat applies a decimal operation to the shortest decimal representation of the floats of a value, so that
+// e.g. 0.1 is not operated on as 0.1000000000000000055511151231257827
+func decimalFloat(value *reflect.Value, operation func(number *big.Rat) *big.Rat) floatOperation {
+	return func(number float64) (float64, error) {
+		if math.IsNaN(number) || math.IsInf(number, 0) {
+			return number, nil
+		}
+
+		bitSize := 64
+		if value.Kind() == reflect.Float32 || value.Kind() == reflect.Complex64 {
+			bitSize = 32
+		}
+
+		decimal, _ := new(big.Rat).SetString(strconv.FormatFloat(number, 'g', -1, bitSize))
+		result := operation(decimal)
+		if bitSize == 32 {
+			rounded, _ := result.Float32()
+			return float64(rounded), nil
+		}
+
+		rounded, _ := result.Float64()
+		return rounded, nil
+	}
+}
+
+// roundDecimal rounds a number to the given count of decimal places, negative places round to tens, hundreds, etc.
+func roundDecimal(number *big.Rat, places int, mode string) *big.Rat {
+	exponent := places
+	if exponent < 0 {
+		exponent = -exponent
+	}
+
+	scale := new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(exponent)), nil))
+	scaled := new(big.Rat)
+	if places >= 0 {
+		scaled.Mul(number, scale)
+	} else {
+		scaled.Quo(number, scale)
+	}
+
+	quotient, remainder := new(big.Int).QuoRem(scaled.Num(), scaled.Denom(), new(big.Int))
+	if remainder.Sign() != 0 && isRoundedAway(quotient, remainder, scaled.Denom(), scaled.Sign(), mode) {
+		quotient.Add(quotient, big.NewInt(int64(scaled.Sign())))
+	}
+
+	result := new(big.Rat).SetInt(quotient)
+	if places >= 0 {
+		return result.Quo(result, scale)
+	}
+
+	return result.Mul(result, scale)
+}
+
+func isRoundedAway(quotient, remainder, denominator *big.Int, sign int, mode string) bool {
+	switch mode {
+	case RoundUp:
+		return true
+	case RoundCeil:
+		return sign > 0
+	case RoundFloor:
+		return sign < 0
+	case RoundHalfUp, RoundHalfEven:
+		half := new(big.Int).Abs(new(big.Int).Lsh(remainder, 1)).Cmp(denominator)
+		return half > 0 || half == 0 && (mode == RoundHalfUp || quotient.Bit(0) == 1)
+	}
+
+	return false
+}
+
+// transformDecimal applies a decimal operation to numbers of any kind including big.Int, big.Float and big.Rat.
+// Floats are converted using their shortest decimal representation, so "1.005" is treated as exactly 1.005.
+func transformDecimal(value *reflect.Value, tag string, operation func(number *big.Rat) *big.Rat) error {
+	switch value.Type() {
+	case bigIntType:
+		number := value.Addr().Interface().(*big.Int)
+		number.Set(operation(new(big.Rat).SetInt(number)).Num())
+		return nil
+	case bigRatType:
+		number := value.Addr().Interface().(*big.Rat)
+		number.Set(operation(number))
+		return nil
+	case bigFloatType:
+		number := value.Addr().Interface().(*big.Float)
+		if number.IsInf() {
+			return nil
+		}
+
+		decimal, _ := new(big.Rat).SetString(number.Text('g', -1))
+		number.SetRat(operation(decimal))
+		return nil
+	}
+
+	return transformNumber(value, tag, true, func(number *big.Int) (*big.Int, error) {
+		return operation(new(big.Rat).SetInt(number)).Num(), nil
+	}, decimalFloat(value, operation))
+}
+
+//endregion helpers
+
+//region Min
+
+type minTransformer struct {
+	NumbersParameterTransformer
+}
+
+func (t *minTransformer) Transform(value *reflect.Value, key *string) error {
+	params, err := t.get(key)
+	if err != nil {
+		return err
+	}
+
+	return transformNumber(value, TagMin, false, func(number *big.Int) (*big.Int, error) {
+		limit, err := t.toInteger(key, params[0])
+		if err != nil || number.Cmp(limit) >= 0 {
+			return number, err
+		}
+
+		return limit, nil
+	}, func(number float64) (float64, error) {
+		return math.Max(number, toFloat(params[0])), nil
+	})
+}
+
+//endregion Min
+
+//region Max
+
+type maxTransformer struct {
+	NumbersParameterTransformer
+}
+
+func (t *maxTransformer) Transform(value *reflect.Value, key *string) error {
+	params, err := t.get(key)
+	if err != nil {
+		return err
+	}
+
+	return transformNumber(value, TagMax, false, func(number *big.Int) (*big.Int, error) {
+		limit, err := t.toInteger(key, params[0])
+		if err != nil || number.Cmp(limit) <= 0 {
+			return number, err
+		}
+
+		return limit, nil
+	}, func(number float64) (float64, error) {
+		return math.Min(number, toFloat(params[0])), nil
+	})
+}
+
+//endregion Max
+
+//region Clamp
+
+type clampTransformer struct {
+	NumbersParameterTransformer
+}
+
+func (t *clampTransformer) Cache(params, key *string) error {
+	if err := t.NumbersParameterTransformer.Cache(params, key); err != nil {
+		return err
+	}
+
+	values, _ := t.get(key)
+	if values[0].Cmp(values[1]) > 0 {
+		return newErrorf(ErrInvalidParameters, *params, TagClamp)
+	}
+
+	return nil
+}
+
+func (t *clampTransformer) Transform(value *reflect.Value, key *string) error {
+	params, err := t.get(key)
+	if err != nil {
+		return err
+	}
+
+	return transformNumber(value, TagClamp, false, func(number *big.Int) (*big.Int, error) {
+		low, err := t.toInteger(key, params[0])
+		if err != nil {
+			return nil, err
+		}
+
+		high, err := t.toInteger(key, params[1])
+		if err != nil {
+			return nil, err
+		}
+
+		if number.Cmp(low) < 0 {
+			return low, nil
+		}
+
+		if number.Cmp(high) > 0 {
+			return high, nil
+		}
+
+		return number, nil
+	}, func(number float64) (float64, error) {
+		return math.Min(math.Max(number, toFloat(params[0])), toFloat(params[1])), nil
+	})
+}
+
+//endregion Clamp
+
+//region Abs
+
+type absTransformer struct {
+	ParameterlessTransformer
+}
+
+func (t *absTransformer) Transform(value *reflect.Value, _ *string) error {
+	return transformNumber(value, TagAbs, false, func(number *big.Int) (*big.Int, error) {
+		return number.Abs(number), nil
+	}, func(number float64) (float64, error) {
+		return math.Abs(number), nil
+	})
+}
+
+//endregion Abs
+
+//region Multiple
+
+type multipleTransformer struct {
+	NumbersParameterTransformer
+}
+
+func (t *multipleTransformer) Cache(params, key *string) error {
+	if err := t.NumbersParameterTransformer.Cache(params, key); err != nil {
+		return err
+	}
+
+	values, _ := t.get(key)
+	if values[0].Sign() <= 0 {
+		return newErrorf(ErrInvalidParameters, *params, TagMultiple)
+	}
+
+	return nil
+}
+
+func (t *multipleTransformer) Transform(value *reflect.Value, key *string) error {
+	params, err := t.get(key)
+	if err != nil {
+		return err
+	}
+
+	return transformNumber(value, TagMultiple, false, func(number *big.Int) (*big.Int, error) {
+		multiple, err := t.toInteger(key, params[0])
+		if err != nil {
+			return nil, err
+		}
+
+		return roundToMultiple(number, multiple), nil
+	}, decimalFloat(value, func(number *big.Rat) *big.Rat {
+		return roundRatToMultiple(number, params[0])
+	}))
+}
+
+//endregion Multiple
+
+//region RoundTo
+
+type roundToTransformer struct {
+	IntParameterTransformer
+}
+
+func (t *roundToTransformer) Cache(params, key *string) error {
+	places, err := strconv.Atoi(*params)
+	if err != nil || places > maxDecimalPlaces || places < -maxDecimalPlaces {
+		return newErrorf(ErrInvalidParameters, *params, TagRoundTo)
+	}
+
+	return t.IntParameterTransformer.Cache(params, key)
+}
+
+func (t *roundToTransformer) Transform(value *reflect.Value, key *string) error {
+	t.Mutex.RLock()
+	places, ok := t.Values[*key]
+	t.Mutex.RUnlock()
+
+	if !ok {
+		return newErrorf(ErrMissingParametersFmt, TagRoundTo)
+	}
+
+	return transformDecimal(value, TagRoundTo, func(number *big.Rat) *big.Rat {
+		return roundDecimal(number, *places, RoundHalfUp)
+	})
+}
+
+//endregion RoundTo
@@ -0,0 +1,284 @@
+/*
+	MIT License
+
+	Copyright (c) 2022 Antony Jekov
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in all
+	copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+	SOFTWARE.
+*/
+
+package morph
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// the layouts accepted by TagDate after the ones set in the tag, from the most to the least specific
+var defaultDateLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05Z07:00",
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04",
+	"2006-01-02",
+	"2006/01/02",
+	"20060102",
+	"2.1.2006",
+	"2 Jan 2006",
+	"2 January 2006",
+	"Jan 2 2006",
+	"January 2 2006",
+	"Jan 2, 2006",
+	"January 2, 2006",
+	time.RFC1123Z,
+	time.RFC1123,
+	time.RFC850,
+	time.RFC822Z,
+	time.RFC822,
+	time.ANSIC,
+	time.UnixDate,
+}
+
+// the words accepted by TagBool, which are compared case-insensitively
+var boolWords = map[string]bool{
+	"true":  true,
+	"t":     true,
+	"yes":   true,
+	"y":     true,
+	"on":    true,
+	"1":     true,
+	"false": false,
+	"f":     false,
+	"no":    false,
+	"n":     false,
+	"off":   false,
+	"0":     false,
+}
+
+type dateLayouts struct {
+	output string
+	inputs []string
+}
+
+//region Numeric
+
+type numericTransformer struct {
+	ParameterTransformer
+}
+
+func newNumericTransformer(mutex *sync.RWMutex) *numericTransformer {
+	return &numericTransformer{NewParamsTransformer(mutex, func(params string) (interface{}, error) {
+		switch params {
+		case "":
+			return rune(0), nil
+		case NumericDecimalDot:
+			return '.', nil
+		case NumericDecimalComma:
+			return ',', nil
+		}
+
+		return nil, newErrorf(ErrInvalidParameters, params, TagNumeric)
+	})}
+}
+
+func (t *numericTransformer) Transform(value *reflect.Value, key *string) error {
+	decimal, ok := t.Get(key)
+	if !ok {
+		return newErrorf(ErrMissingParametersFmt, TagNumeric)
+	}
+
+	return transformCanonicalString(value, TagNumeric, func(number string) (string, bool) {
+		return normalizeNumeric(number, decimal.(rune))
+	})
+}
+
+// normalizeNumeric parses a decimal number with an optional sign, digit groups of three and a decimal separator,
+// which is detected when it is not set, and formats it without groups, with a dot and without redundant zeros
+func normalizeNumeric(number string, decimal rune) (string, bool) {
+	negative := false
+	if r, size := utf8.DecodeRuneInString(number); r == '+' || r == '-' || r == '\u2212' {
+		negative = r != '+'
+		number = number[size:]
+	}
+
+	if decimal == 0 {
+		decimal = detectDecimalSeparator(number)
+	}
+
+	integer, fraction := number, ""
+	if i := strings.IndexRune(number, decimal); decimal != 0 && i >= 0 {
+		integer, fraction = number[:i], number[i+utf8.RuneLen(decimal):]
+	}
+
+	integer, ok := ungroupDigits(integer, decimal)
+	if !ok || !isDigits(fraction) || len(integer)+len(fraction) == 0 {
+		return "", false
+	}
+
+	integer = strings.TrimLeft(integer, "0")
+	if len(integer) == 0 {
+		integer = "0"
+	}
+
+	fraction = strings.TrimRight(fraction, "0")
+	if len(fraction) > 0 {
+		integer += "." + fraction
+	}
+
+	if negative && integer != "0" {
+		return "-" + integer, true
+	}
+
+	return integer, true
+}
+
+// detectDecimalSeparator returns the last one of dot and comma if it occurs once, otherwise both are treated as group
+// separators, so "1,234" is read as 1.234 and NumericDecimalDot has to be used for 1234
+func detectDecimalSeparator(number string) rune {
+	last := strings.LastIndexAny(number, ".,")
+	if last < 0 {
+		return 0
+	}
+
+	if separator := rune(number[last]); strings.Count(number, string(separator)) == 1 {
+		return separator
+	}
+
+	return 0
+}
+
+// ungroupDigits removes the group separators of the integer part of a number, which have to be the same and to split
+// it in groups of three digits, except for the first one
+func ungroupDigits(integer string, decimal rune) (string, bool) {
+	var digits strings.Builder
+	var separator rune
+	group, groups := 0, 0
+	for _, r := range integer {
+		if '0' <= r && r <= '9' {
+			digits.WriteRune(r)
+			group++
+			continue
+		}
+
+		if !isGroupSeparator(r, decimal) || (separator != 0 && r != separator) || group == 0 ||
+			(groups == 0 && group > 3) || (groups > 0 && group != 3) {
+			return "", false
+		}
+
+		separator = r
+		group = 0
+		groups++
+	}
+
+	if groups > 0 && group != 3 {
+		return "", false
+	}
+
+	return digits.String(), true
+}
+
+func isGroupSeparator(r, decimal rune) bool {
+	if r == decimal {
+		return false
+	}
+
+	switch r {
+	case '.', ',', '\'', '’', ' ', '\u00a0', '\u202f':
+		return true
+	}
+
+	return false
+}
+
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+//endregion Numeric
+
+//region Bool
+
+type boolTransformer struct {
+	ParameterlessTransformer
+}
+
+func (t *boolTransformer) Transform(value *reflect.Value, _ *string) error {
+	return transformCanonicalString(value, TagBool, func(word string) (string, bool) {
+		parsed, ok := boolWords[strings.ToLower(word)]
+		if !ok {
+			return "", false
+		}
+
+		if parsed {
+			return "true", true
+		}
+
+		return "false", true
+	})
+}
+
+//endregion Bool
+
+//region Date
+
+type dateTransformer struct {
+	ParameterTransformer
+}
+
+func newDateTransformer(mutex *sync.RWMutex) *dateTransformer {
+	return &dateTransformer{NewParamsTransformer(mutex, func(params string) (interface{}, error) {
+		layouts := strings.Split(params, "|")
+		for _, layout := range layouts {
+			if len(strings.TrimSpace(layout)) == 0 {
+				return nil, newErrorf(ErrMissingParametersFmt, TagDate)
+			}
+		}
+
+		return &dateLayouts{layouts[0], append(layouts, defaultDateLayouts...)}, nil
+	})}
+}
+
+func (t *dateTransformer) Transform(value *reflect.Value, key *string) error {
+	layouts, ok := t.Get(key)
+	if !ok {
+		return newErrorf(ErrMissingParametersFmt, TagDate)
+	}
+
+	return transformCanonicalString(value, TagDate, func(date string) (string, bool) {
+		for _, layout := range layouts.(*dateLayouts).inputs {
+			if parsed, err := time.Parse(layout, date); err == nil {
+				return parsed.Format(layouts.(*dateLayouts).output), true
+			}
+		}
+
+		return "", false
+	})
+}
+
+//endregion Date
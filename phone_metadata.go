@@ -0,0 +1,107 @@
+/*
+	MIT License
+
+	Copyright (c) 2022 Antony Jekov
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in all
+	copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+	SOFTWARE.
+*/
+
+package morph
+
+type phoneRegion struct {
+	region              string
+	callingCode         string
+	nationalPrefix      string
+	internationalPrefix string
+	minLength           int
+	maxLength           int
+}
+
+// phoneRegions is a simplified numbering plan holding the lengths of the national significant numbers and the
+// dialing prefixes of each region. Regions sharing a calling code are listed after the main one.
+var phoneRegions = []phoneRegion{
+	{"US", "1", "1", "011", 10, 10},
+	{"CA", "1", "1", "011", 10, 10},
+	{"PR", "1", "1", "011", 10, 10},
+	{"RU", "7", "8", "810", 10, 10},
+	{"KZ", "7", "8", "810", 10, 10},
+	{"EG", "20", "0", "00", 8, 10},
+	{"ZA", "27", "0", "00", 9, 9},
+	{"GR", "30", "", "00", 10, 10},
+	{"NL", "31", "0", "00", 9, 11},
+	{"BE", "32", "0", "00", 8, 9},
+	{"FR", "33", "0", "00", 9, 9},
+	{"ES", "34", "", "00", 9, 9},
+	{"HU", "36", "06", "00", 8, 9},
+	{"IT", "39", "", "00", 6, 12},
+	{"RO", "40", "0", "00", 9, 9},
+	{"CH", "41", "0", "00", 9, 12},
+	{"AT", "43", "0", "00", 4, 13},
+	{"GB", "44", "0", "00", 7, 10},
+	{"DK", "45", "", "00", 8, 8},
+	{"SE", "46", "0", "00", 7, 10},
+	{"NO", "47", "", "00", 8, 8},
+	{"PL", "48", "", "00", 9, 9},
+	{"DE", "49", "0", "00", 4, 13},
+	{"PE", "51", "0", "00", 8, 9},
+	{"MX", "52", "", "00", 10, 10},
+	{"AR", "54", "0", "00", 10, 11},
+	{"BR", "55", "0", "00", 10, 11},
+	{"CL", "56", "", "00", 9, 9},
+	{"CO", "57", "0", "00", 8, 10},
+	{"AU", "61", "0", "0011", 9, 9},
+	{"NZ", "64", "0", "00", 8, 10},
+	{"SG", "65", "", "000", 8, 8},
+	{"JP", "81", "0", "010", 9, 10},
+	{"KR", "82", "0", "001", 8, 10},
+	{"CN", "86", "0", "00", 7, 12},
+	{"TR", "90", "0", "00", 10, 10},
+	{"IN", "91", "0", "00", 10, 10},
+	{"NG", "234", "0", "009", 8, 10},
+	{"PT", "351", "", "00", 9, 9},
+	{"IE", "353", "0", "00", 7, 10},
+	{"FI", "358", "0", "00", 5, 12},
+	{"BG", "359", "0", "00", 6, 9},
+	{"UA", "380", "0", "00", 9, 9},
+	{"RS", "381", "0", "00", 6, 12},
+	{"HR", "385", "0", "00", 8, 9},
+	{"SI", "386", "0", "00", 8, 8},
+	{"CZ", "420", "", "00", 9, 9},
+	{"SK", "421", "0", "00", 4, 9},
+	{"HK", "852", "", "001", 8, 8},
+	{"AE", "971", "0", "00", 8, 9},
+	{"IL", "972", "0", "00", 8, 9},
+	{"SA", "966", "0", "00", 9, 9},
+}
+
+var phoneRegionsByName, phoneRegionsByCode = indexPhoneRegions()
+
+func indexPhoneRegions() (map[string]*phoneRegion, map[string]*phoneRegion) {
+	byName := make(map[string]*phoneRegion, len(phoneRegions))
+	byCode := make(map[string]*phoneRegion)
+	for i := range phoneRegions {
+		region := &phoneRegions[i]
+		byName[region.region] = region
+		if _, ok := byCode[region.callingCode]; !ok {
+			byCode[region.callingCode] = region
+		}
+	}
+
+	return byName, byCode
+}
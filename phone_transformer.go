@@ -0,0 +1,157 @@
+/*
+	MIT License
+
+	Copyright (c) 2022 Antony Jekov
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in all
+	copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+	SOFTWARE.
+*/
+
+package morph
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+)
+
+const (
+	minPhoneLength = 7
+	maxPhoneLength = 15
+)
+
+type phoneOptions struct {
+	region      *phoneRegion
+	passthrough bool
+}
+
+type phoneTransformer struct {
+	ParameterTransformer
+}
+
+func newPhoneTransformer(mutex *sync.RWMutex) *phoneTransformer {
+	return &phoneTransformer{NewParamsTransformer(mutex, parsePhoneOptions)}
+}
+
+func parsePhoneOptions(params string) (interface{}, error) {
+	options := &phoneOptions{}
+	for _, option := range strings.Fields(params) {
+		if option == PhonePassthrough {
+			options.passthrough = true
+			continue
+		}
+
+		region, ok := phoneRegionsByName[strings.ToUpper(option)]
+		if !ok || options.region != nil {
+			return nil, newErrorf(ErrInvalidParameters, params, TagPhone)
+		}
+		options.region = region
+	}
+
+	return options, nil
+}
+
+func (t *phoneTransformer) Transform(value *reflect.Value, key *string) error {
+	parsed, ok := t.Get(key)
+	if !ok {
+		return newErrorf(ErrMissingParametersFmt, TagPhone)
+	}
+
+	if value.Kind() != reflect.String {
+		return newErrorf(ErrUnexpectedValue, value.Type().Kind().String(), TagPhone)
+	}
+
+	options := parsed.(*phoneOptions)
+	phone, ok := formatE164(value.String(), options.region)
+	if !ok {
+		if options.passthrough {
+			return nil
+		}
+
+		return newErrorf(ErrInvalidValueFmt, value.String(), TagPhone)
+	}
+
+	value.SetString(phone)
+	return nil
+}
+
+// formatE164 strips the formatting of a phone number and returns it in the E.164 format. National numbers are read
+// using the dialing prefixes of the default region and are rejected if there is no such region. International
+// numbers with a calling code which has no region metadata are only checked against the length limits of E.164. Empty
+// values are left empty.
+func formatE164(phone string, region *phoneRegion) (string, bool) {
+	phone = strings.TrimFunc(phone, isBlank)
+	if len(phone) == 0 {
+		return "", true
+	}
+
+	international := strings.HasPrefix(phone, "+")
+	if international {
+		// the national prefix is often written in brackets after the calling code, e.g. +44 (0)20 7946 0958
+		phone = strings.Replace(phone[1:], "(0)", "", 1)
+	}
+
+	var builder strings.Builder
+	for _, r := range phone {
+		switch {
+		case r >= '0' && r <= '9':
+			builder.WriteRune(r)
+		case isBlank(r) || strings.ContainsRune("-./()", r):
+		default:
+			return "", false
+		}
+	}
+
+	digits := builder.String()
+	if !international {
+		if region == nil {
+			return "", false
+		}
+
+		if strings.HasPrefix(digits, region.internationalPrefix) {
+			digits = digits[len(region.internationalPrefix):]
+		} else {
+			national := strings.TrimPrefix(digits, region.nationalPrefix)
+			if !isPhoneLength(national, region) {
+				national = digits
+			}
+			digits = region.callingCode + national
+		}
+	}
+
+	for i := 1; i <= 3 && i < len(digits); i++ {
+		if region, ok := phoneRegionsByCode[digits[:i]]; ok {
+			if !isPhoneLength(digits[i:], region) || len(digits) > maxPhoneLength {
+				return "", false
+			}
+
+			return "+" + digits, true
+		}
+	}
+
+	// numbers with a calling code without region metadata are only checked against the length limits of E.164
+	if len(digits) < minPhoneLength || len(digits) > maxPhoneLength || digits[0] == '0' {
+		return "", false
+	}
+
+	return "+" + digits, true
+}
+
+func isPhoneLength(national string, region *phoneRegion) bool {
+	return len(national) >= region.minLength && len(national) <= region.maxLength
+}
@@ -0,0 +1,178 @@
+/*
+	MIT License
+
+	Copyright (c) 2022 Antony Jekov
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in all
+	copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+	SOFTWARE.
+*/
+
+package morph
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+type regexpReplacement struct {
+	pattern     *regexp.Regexp
+	replacement string
+}
+
+//region Replace
+
+type replaceTransformer struct {
+	ParameterTransformer
+}
+
+func newReplaceTransformer(mutex *sync.RWMutex) *replaceTransformer {
+	return &replaceTransformer{NewParamsTransformer(mutex, parseReplacement)}
+}
+
+func parseReplacement(params string) (interface{}, error) {
+	expression, replacement := params, ""
+	for i := 0; i < len(params); i++ {
+		if params[i] == '\\' {
+			i++
+			continue
+		}
+
+		if params[i] == ' ' {
+			expression, replacement = params[:i], params[i+1:]
+			break
+		}
+	}
+
+	// an escaped space is a literal space of the expression, which regexp does not accept as an escape
+	pattern, err := compilePattern(strings.ReplaceAll(expression, "\\ ", "\\x20"), TagReplace)
+	if err != nil {
+		return nil, err
+	}
+
+	return &regexpReplacement{pattern, replacement}, nil
+}
+
+func (t *replaceTransformer) Transform(value *reflect.Value, key *string) error {
+	return transformRegexp(value, key, TagReplace, &t.ParameterTransformer)
+}
+
+//endregion Replace
+
+//region Remove
+
+type removeTransformer struct {
+	ParameterTransformer
+}
+
+func newRemoveTransformer(mutex *sync.RWMutex) *removeTransformer {
+	return &removeTransformer{NewParamsTransformer(mutex, func(params string) (interface{}, error) {
+		pattern, err := compilePattern(params, TagRemove)
+		if err != nil {
+			return nil, err
+		}
+
+		return &regexpReplacement{pattern: pattern}, nil
+	})}
+}
+
+func (t *removeTransformer) Transform(value *reflect.Value, key *string) error {
+	return transformRegexp(value, key, TagRemove, &t.ParameterTransformer)
+}
+
+//endregion Remove
+
+//region Keep
+
+type keepTransformer struct {
+	ParameterTransformer
+}
+
+func newKeepTransformer(mutex *sync.RWMutex) *keepTransformer {
+	return &keepTransformer{NewParamsTransformer(mutex, func(params string) (interface{}, error) {
+		class := params
+		if strings.HasPrefix(class, "[") && strings.HasSuffix(class, "]") && len(class) > 1 {
+			class = class[1 : len(class)-1]
+		}
+
+		if len(class) == 0 || strings.HasPrefix(class, "^") || closesClass(class) {
+			return nil, newErrorf(ErrInvalidParameters, params, TagKeep)
+		}
+
+		pattern, err := compilePattern("[^"+class+"]", TagKeep)
+		if err != nil {
+			return nil, newErrorf(ErrInvalidParameters, params, TagKeep)
+		}
+
+		return &regexpReplacement{pattern: pattern}, nil
+	})}
+}
+
+func (t *keepTransformer) Transform(value *reflect.Value, key *string) error {
+	return transformRegexp(value, key, TagKeep, &t.ParameterTransformer)
+}
+
+//endregion Keep
+
+// closesClass reports whether the content of a character class contains an unescaped "]" closing the class early,
+// which would let the rest of the parameters be read as an expression (e.g. "a]|[b"). A leading "]" and the ones
+// of the POSIX classes such as "[:alpha:]" are part of the class.
+func closesClass(class string) bool {
+	for i := 0; i < len(class); i++ {
+		switch {
+		case class[i] == '\\':
+			i++
+		case strings.HasPrefix(class[i:], "[:"):
+			end := strings.Index(class[i+2:], ":]")
+			if end < 0 {
+				return true
+			}
+			i += end + 3
+		case class[i] == ']' && i > 0:
+			return true
+		}
+	}
+
+	return false
+}
+
+func compilePattern(expression, tag string) (*regexp.Regexp, error) {
+	if len(expression) == 0 {
+		return nil, newErrorf(ErrMissingParametersFmt, tag)
+	}
+
+	pattern, err := regexp.Compile(expression)
+	if err != nil {
+		return nil, newErrorf(ErrInvalidParameters, expression, tag)
+	}
+
+	return pattern, nil
+}
+
+func transformRegexp(value *reflect.Value, key *string, tag string, params *ParameterTransformer) error {
+	parsed, ok := params.Get(key)
+	if !ok {
+		return newErrorf(ErrMissingParametersFmt, tag)
+	}
+
+	replacement := parsed.(*regexpReplacement)
+	return transformString(value, tag, func(s string) string {
+		return replacement.pattern.ReplaceAllString(s, replacement.replacement)
+	})
+}
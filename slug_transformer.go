@@ -0,0 +1,154 @@
+/*
+	MIT License
+
+	Copyright (c) 2022 Antony Jekov
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in all
+	copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+	SOFTWARE.
+*/
+
+package morph
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+type slugOptions struct {
+	separator string
+	maxLength int
+	unicode   bool
+	stopWords map[string]bool
+}
+
+type slugTransformer struct {
+	ParameterTransformer
+}
+
+func newSlugTransformer(mutex *sync.RWMutex) *slugTransformer {
+	return &slugTransformer{NewParamsTransformer(mutex, parseSlugOptions)}
+}
+
+func parseSlugOptions(params string) (interface{}, error) {
+	options := &slugOptions{separator: "-"}
+	for _, option := range strings.Fields(params) {
+		name, value := option, ""
+		if i := strings.IndexRune(option, ParamsSign); i > 0 {
+			name, value = option[:i], option[i+1:]
+		}
+
+		switch name {
+		case SlugSeparator:
+			options.separator = value
+		case SlugMaxLength:
+			maxLength, err := strconv.Atoi(value)
+			if err != nil || maxLength <= 0 {
+				return nil, newErrorf(ErrInvalidParameters, params, TagSlug)
+			}
+			options.maxLength = maxLength
+		case SlugUnicode:
+			options.unicode = true
+		case SlugStopWords:
+			options.stopWords = make(map[string]bool)
+			for _, word := range strings.Split(value, "|") {
+				options.stopWords[strings.ToLower(word)] = true
+			}
+		default:
+			return nil, newErrorf(ErrInvalidParameters, params, TagSlug)
+		}
+	}
+
+	return options, nil
+}
+
+func (t *slugTransformer) Transform(value *reflect.Value, key *string) error {
+	options, ok := t.Get(key)
+	if !ok {
+		return newErrorf(ErrMissingParametersFmt, TagSlug)
+	}
+
+	return transformString(value, TagSlug, func(s string) string {
+		return slugify(s, options.(*slugOptions))
+	})
+}
+
+func slugify(s string, options *slugOptions) string {
+	if options.unicode {
+		s = normalize(s, TagNFC)
+	} else {
+		s = toASCII(s)
+	}
+
+	s = strings.Map(func(r rune) rune {
+		if r == '\'' || r == '’' {
+			return -1
+		}
+
+		return unicode.ToLower(r)
+	}, s)
+
+	words := strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && !unicode.IsMark(r)
+	})
+
+	if len(options.stopWords) > 0 {
+		kept := make([]string, 0, len(words))
+		for _, word := range words {
+			if !options.stopWords[word] {
+				kept = append(kept, word)
+			}
+		}
+
+		if len(kept) > 0 {
+			words = kept
+		}
+	}
+
+	if options.maxLength == 0 {
+		return strings.Join(words, options.separator)
+	}
+
+	var slug strings.Builder
+	length, separatorLength := 0, utf8.RuneCountInString(options.separator)
+	for i, word := range words {
+		wordLength := utf8.RuneCountInString(word)
+		if i > 0 {
+			wordLength += separatorLength
+		}
+
+		if length+wordLength > options.maxLength {
+			if i == 0 {
+				slug.WriteString(string([]rune(word)[:options.maxLength]))
+			}
+			break
+		}
+
+		if i > 0 {
+			slug.WriteString(options.separator)
+		}
+
+		slug.WriteString(word)
+		length += wordLength
+	}
+
+	return slug.String()
+}
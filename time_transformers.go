@@ -0,0 +1,284 @@
+/*
+	MIT License
+
+	Copyright (c) 2022 Antony Jekov
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in all
+	copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+	SOFTWARE.
+*/
+
+package morph
+
+import (
+	"reflect"
+	"sync"
+	"time"
+)
+
+// timeBound is a parsed time limit, which is resolved on each transformation when it is the current time
+type timeBound struct {
+	now   bool
+	value time.Time
+}
+
+func (b *timeBound) get() time.Time {
+	if b.now {
+		return time.Now()
+	}
+
+	return b.value
+}
+
+//region UTC
+
+type utcTransformer struct {
+	ParameterlessTransformer
+}
+
+func (t *utcTransformer) Transform(value *reflect.Value, _ *string) error {
+	return transformTime(value, TagUTC, func(t time.Time) time.Time {
+		return t.UTC()
+	})
+}
+
+//endregion UTC
+
+//region TimeZone
+
+type timeZoneTransformer struct {
+	ParameterTransformer
+}
+
+func newTimeZoneTransformer(mutex *sync.RWMutex) *timeZoneTransformer {
+	return &timeZoneTransformer{NewParamsTransformer(mutex, func(params string) (interface{}, error) {
+		if len(params) == 0 {
+			return nil, newErrorf(ErrMissingParametersFmt, TagTimeZone)
+		}
+
+		location, err := time.LoadLocation(params)
+		if err != nil {
+			return nil, newErrorf(ErrInvalidParameters, params, TagTimeZone)
+		}
+
+		return location, nil
+	})}
+}
+
+func (t *timeZoneTransformer) Transform(value *reflect.Value, key *string) error {
+	location, ok := t.Get(key)
+	if !ok {
+		return newErrorf(ErrMissingParametersFmt, TagTimeZone)
+	}
+
+	return transformTime(value, TagTimeZone, func(t time.Time) time.Time {
+		return t.In(location.(*time.Location))
+	})
+}
+
+//endregion TimeZone
+
+//region TruncateTime
+
+type truncateTimeTransformer struct {
+	ParameterTransformer
+}
+
+func (t *truncateTimeTransformer) Transform(value *reflect.Value, key *string) error {
+	duration, ok := t.Get(key)
+	if !ok {
+		return newErrorf(ErrMissingParametersFmt, TagTruncateTime)
+	}
+
+	return transformTime(value, TagTruncateTime, func(t time.Time) time.Time {
+		return t.Truncate(duration.(time.Duration))
+	})
+}
+
+//endregion TruncateTime
+
+//region StartOfDay
+
+type startOfDayTransformer struct {
+	ParameterlessTransformer
+}
+
+func (t *startOfDayTransformer) Transform(value *reflect.Value, _ *string) error {
+	return transformTime(value, TagStartOfDay, func(t time.Time) time.Time {
+		year, month, day := t.Date()
+		return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+	})
+}
+
+//endregion StartOfDay
+
+//region NotAfter
+
+type notAfterTransformer struct {
+	ParameterTransformer
+}
+
+func newNotAfterTransformer(mutex *sync.RWMutex) *notAfterTransformer {
+	return &notAfterTransformer{NewParamsTransformer(mutex, func(params string) (interface{}, error) {
+		if params == TimeNow {
+			return &timeBound{now: true}, nil
+		}
+
+		bound, err := parseDefaultTime(params)
+		if err != nil {
+			return nil, newErrorf(ErrInvalidParameters, params, TagNotAfter)
+		}
+
+		return &timeBound{value: bound}, nil
+	})}
+}
+
+func (t *notAfterTransformer) Transform(value *reflect.Value, key *string) error {
+	bound, ok := t.Get(key)
+	if !ok {
+		return newErrorf(ErrMissingParametersFmt, TagNotAfter)
+	}
+
+	return transformTime(value, TagNotAfter, func(t time.Time) time.Time {
+		if limit := bound.(*timeBound).get(); t.After(limit) {
+			return limit
+		}
+
+		return t
+	})
+}
+
+//endregion NotAfter
+
+//region StripMonotonic
+
+type stripMonotonicTransformer struct {
+	ParameterlessTransformer
+}
+
+func (t *stripMonotonicTransformer) Transform(value *reflect.Value, _ *string) error {
+	return transformTime(value, TagStripMonotonic, func(t time.Time) time.Time {
+		return t.Round(0)
+	})
+}
+
+//endregion StripMonotonic
+
+//region DurationRound
+
+type durationRoundTransformer struct {
+	ParameterTransformer
+	leaves *leafRegistry
+}
+
+func (t *durationRoundTransformer) Transform(value *reflect.Value, key *string) error {
+	multiple, ok := t.Get(key)
+	if !ok {
+		return newErrorf(ErrMissingParametersFmt, TagDurationRound)
+	}
+
+	return transformDuration(value, t.leaves, TagDurationRound, func(d time.Duration) time.Duration {
+		return d.Round(multiple.(time.Duration))
+	})
+}
+
+//endregion DurationRound
+
+//region DurationMin
+
+type durationMinTransformer struct {
+	ParameterTransformer
+	leaves *leafRegistry
+}
+
+func (t *durationMinTransformer) Transform(value *reflect.Value, key *string) error {
+	limit, ok := t.Get(key)
+	if !ok {
+		return newErrorf(ErrMissingParametersFmt, TagDurationMin)
+	}
+
+	return transformDuration(value, t.leaves, TagDurationMin, func(d time.Duration) time.Duration {
+		if d < limit.(time.Duration) {
+			return limit.(time.Duration)
+		}
+
+		return d
+	})
+}
+
+//endregion DurationMin
+
+//region DurationMax
+
+type durationMaxTransformer struct {
+	ParameterTransformer
+	leaves *leafRegistry
+}
+
+func (t *durationMaxTransformer) Transform(value *reflect.Value, key *string) error {
+	limit, ok := t.Get(key)
+	if !ok {
+		return newErrorf(ErrMissingParametersFmt, TagDurationMax)
+	}
+
+	return transformDuration(value, t.leaves, TagDurationMax, func(d time.Duration) time.Duration {
+		if d > limit.(time.Duration) {
+			return limit.(time.Duration)
+		}
+
+		return d
+	})
+}
+
+//endregion DurationMax
+
+// newDurationParamsTransformer parses the params of a tag as a duration, which has to be greater than zero when
+// positive is set
+func newDurationParamsTransformer(mutex *sync.RWMutex, tag string, positive bool) ParameterTransformer {
+	return NewParamsTransformer(mutex, func(params string) (interface{}, error) {
+		if len(params) == 0 {
+			return nil, newErrorf(ErrMissingParametersFmt, tag)
+		}
+
+		duration, err := time.ParseDuration(params)
+		if err != nil || (positive && duration <= 0) {
+			return nil, newErrorf(ErrInvalidParameters, params, tag)
+		}
+
+		return duration, nil
+	})
+}
+
+func transformTime(value *reflect.Value, tag string, transform func(t time.Time) time.Time) error {
+	if !isTimeType(value.Type()) {
+		return newErrorf(ErrUnexpectedValue, value.Type().Kind().String(), tag)
+	}
+
+	transformed := transform(value.Convert(timeType).Interface().(time.Time))
+	value.Set(reflect.ValueOf(transformed).Convert(value.Type()))
+	return nil
+}
+
+func transformDuration(value *reflect.Value, leaves *leafRegistry, tag string,
+	transform func(d time.Duration) time.Duration) error {
+	if !isDurationType(value.Type(), leaves) {
+		return newErrorf(ErrUnexpectedValue, value.Type().Kind().String(), tag)
+	}
+
+	value.SetInt(int64(transform(time.Duration(value.Int()))))
+	return nil
+}
@@ -0,0 +1,419 @@
+/*
+	MIT License
+
+	Copyright (c) 2022 Antony Jekov
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in all
+	copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+	SOFTWARE.
+*/
+
+package morph
+
+//go:generate go run gen_unicode_tables.go
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+type decomposition struct {
+	canonical     []rune
+	compatibility []rune
+	variant       bool
+}
+
+type unicodeData struct {
+	decompositions  map[rune]*decomposition
+	combiningRanges []combiningRange
+	compositions    map[[2]rune]rune
+	caseFoldings    map[rune][]rune
+	confusables     map[rune][]rune
+}
+
+type combiningRange struct {
+	first, last rune
+	class       uint8
+}
+
+var (
+	unicodeTables     *unicodeData
+	unicodeTablesOnce sync.Once
+)
+
+// getUnicodeData parses the embedded tables on first use.
+func getUnicodeData() *unicodeData {
+	unicodeTablesOnce.Do(func() {
+		data := &unicodeData{
+			decompositions: make(map[rune]*decomposition),
+			compositions:   make(map[[2]rune]rune),
+			caseFoldings:   make(map[rune][]rune),
+			confusables:    make(map[rune][]rune),
+		}
+
+		forEachTableRow(decompositionTable, func(fields []string) {
+			entry := &decomposition{
+				canonical:     parseRunes(fields[1]),
+				compatibility: parseRunes(fields[2]),
+				variant:       fields[3] == "v",
+			}
+
+			code := parseRune(fields[0])
+			if entry.canonical == nil {
+				entry.canonical = []rune{code}
+			}
+
+			if entry.compatibility == nil {
+				entry.compatibility = entry.canonical
+			}
+
+			data.decompositions[code] = entry
+		})
+
+		forEachTableRow(combiningClassTable, func(fields []string) {
+			class, _ := strconv.Atoi(fields[2])
+			data.combiningRanges = append(data.combiningRanges, combiningRange{
+				parseRune(fields[0]),
+				parseRune(fields[1]),
+				uint8(class),
+			})
+		})
+
+		forEachTableRow(compositionTable, func(fields []string) {
+			data.compositions[[2]rune{parseRune(fields[1]), parseRune(fields[2])}] = parseRune(fields[0])
+		})
+
+		forEachTableRow(caseFoldingTable, func(fields []string) {
+			data.caseFoldings[parseRune(fields[0])] = parseRunes(fields[1])
+		})
+
+		forEachTableRow(confusableTable, func(fields []string) {
+			data.confusables[parseRune(fields[0])] = parseRunes(fields[1])
+		})
+
+		unicodeTables = data
+	})
+
+	return unicodeTables
+}
+
+func forEachTableRow(table string, row func(fields []string)) {
+	for _, line := range strings.Split(table, "\n") {
+		if len(line) > 0 {
+			row(strings.Split(line, ";"))
+		}
+	}
+}
+
+func parseRune(code string) rune {
+	value, _ := strconv.ParseUint(code, 16, 32)
+	return rune(value)
+}
+
+func parseRunes(codes string) []rune {
+	if len(codes) == 0 {
+		return nil
+	}
+
+	fields := strings.Fields(codes)
+	runes := make([]rune, len(fields))
+	for i, field := range fields {
+		runes[i] = parseRune(field)
+	}
+
+	return runes
+}
+
+func (d *unicodeData) combiningClass(r rune) uint8 {
+	if r < 0x300 {
+		return 0
+	}
+
+	i := sort.Search(len(d.combiningRanges), func(i int) bool {
+		return d.combiningRanges[i].last >= r
+	})
+
+	if i < len(d.combiningRanges) && d.combiningRanges[i].first <= r {
+		return d.combiningRanges[i].class
+	}
+
+	return 0
+}
+
+//region Hangul
+
+const (
+	hangulBase   = 0xAC00
+	hangulLBase  = 0x1100
+	hangulVBase  = 0x1161
+	hangulTBase  = 0x11A7
+	hangulLCount = 19
+	hangulVCount = 21
+	hangulTCount = 28
+	hangulNCount = hangulVCount * hangulTCount
+	hangulSCount = hangulLCount * hangulNCount
+)
+
+func decomposeHangul(r rune) []rune {
+	index := r - hangulBase
+	l := hangulLBase + index/hangulNCount
+	v := hangulVBase + (index%hangulNCount)/hangulTCount
+	t := hangulTBase + index%hangulTCount
+
+	if t == hangulTBase {
+		return []rune{l, v}
+	}
+
+	return []rune{l, v, t}
+}
+
+func composeHangul(first, second rune) (rune, bool) {
+	if first >= hangulLBase && first < hangulLBase+hangulLCount &&
+		second >= hangulVBase && second < hangulVBase+hangulVCount {
+		return hangulBase + ((first-hangulLBase)*hangulVCount+second-hangulVBase)*hangulTCount, true
+	}
+
+	if first >= hangulBase && first < hangulBase+hangulSCount && (first-hangulBase)%hangulTCount == 0 &&
+		second > hangulTBase && second < hangulTBase+hangulTCount {
+		return first + second - hangulTBase, true
+	}
+
+	return 0, false
+}
+
+//endregion Hangul
+
+// decompose returns the full canonical or compatibility decomposition of a string in canonical order.
+func (d *unicodeData) decompose(s string, compatibility bool) []rune {
+	runes := make([]rune, 0, len(s))
+	for _, r := range s {
+		switch entry := d.decompositions[r]; {
+		case r >= hangulBase && r < hangulBase+hangulSCount:
+			runes = append(runes, decomposeHangul(r)...)
+		case entry == nil:
+			runes = append(runes, r)
+		case compatibility:
+			runes = append(runes, entry.compatibility...)
+		default:
+			runes = append(runes, entry.canonical...)
+		}
+	}
+
+	d.reorder(runes)
+	return runes
+}
+
+// reorder sorts every sequence of combining characters by their canonical combining class.
+func (d *unicodeData) reorder(runes []rune) {
+	for start := 0; start < len(runes); {
+		if d.combiningClass(runes[start]) == 0 {
+			start++
+			continue
+		}
+
+		end := start
+		for end < len(runes) && d.combiningClass(runes[end]) != 0 {
+			end++
+		}
+
+		marks := runes[start:end]
+		sort.SliceStable(marks, func(i, j int) bool {
+			return d.combiningClass(marks[i]) < d.combiningClass(marks[j])
+		})
+
+		start = end
+	}
+}
+
+// compose applies the canonical composition algorithm to a decomposed sequence of runes.
+func (d *unicodeData) compose(runes []rune) []rune {
+	if len(runes) == 0 {
+		return runes
+	}
+
+	result := make([]rune, 0, len(runes))
+	starter := -1
+	var lastClass uint8
+
+	for _, r := range runes {
+		class := d.combiningClass(r)
+		if starter >= 0 && (lastClass < class || lastClass == 0 && len(result)-1 == starter) {
+			if composite, ok := d.composePair(result[starter], r); ok {
+				result[starter] = composite
+				continue
+			}
+		}
+
+		if class == 0 {
+			starter = len(result)
+		}
+
+		lastClass = class
+		result = append(result, r)
+	}
+
+	return result
+}
+
+func (d *unicodeData) composePair(first, second rune) (rune, bool) {
+	if composite, ok := composeHangul(first, second); ok {
+		return composite, true
+	}
+
+	composite, ok := d.compositions[[2]rune{first, second}]
+	return composite, ok
+}
+
+// normalize converts a string to one of the Unicode normalization forms.
+func normalize(s string, form string) string {
+	if isASCII(s) {
+		return s
+	}
+
+	data := getUnicodeData()
+	switch form {
+	case TagNFD:
+		return string(data.decompose(s, false))
+	case TagNFKD:
+		return string(data.decompose(s, true))
+	case TagNFKC:
+		return string(data.compose(data.decompose(s, true)))
+	}
+
+	return string(data.compose(data.decompose(s, false)))
+}
+
+// caseFold applies the full Unicode case folding used for caseless matching (e.g. "Straße" -> "strasse").
+func caseFold(s string) string {
+	data := getUnicodeData()
+	var result strings.Builder
+	for _, r := range s {
+		if folding, ok := data.caseFoldings[r]; ok {
+			result.WriteString(string(folding))
+			continue
+		}
+
+		result.WriteRune(unicode.ToLower(r))
+	}
+
+	return result.String()
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+
+	return true
+}
+
+// transliterations maps lowercase letters, which do not decompose to ASCII, to their Latin transliteration. Cyrillic
+// follows the Bulgarian streamlined system extended with the Russian, Ukrainian and Serbian letters.
+var transliterations = map[rune]string{
+	'ß': "ss", 'æ': "ae", 'œ': "oe", 'ø': "o", 'đ': "d", 'ł': "l", 'þ': "th", 'ð': "d", 'ı': "i", 'ŋ': "ng",
+	'ħ': "h", 'ŧ': "t", 'ĸ': "k", 'ŀ': "l", 'ſ': "s", '⁄': "/",
+
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ж': "zh", 'з': "z", 'и': "i", 'й': "y", 'к': "k",
+	'л': "l", 'м': "m", 'н': "n", 'о': "o", 'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u", 'ф': "f", 'х': "h",
+	'ц': "ts", 'ч': "ch", 'ш': "sh", 'щ': "sht", 'ъ': "a", 'ь': "y", 'ю': "yu", 'я': "ya", 'ё': "yo", 'ы': "y",
+	'э': "e", 'є': "ye", 'і': "i", 'ї': "yi", 'ґ': "g", 'ђ': "dj", 'ј': "j", 'љ': "lj", 'њ': "nj", 'ћ': "c",
+	'џ': "dz", 'ѓ': "gj", 'ќ': "kj", 'ѕ': "dz",
+
+	'α': "a", 'β': "v", 'γ': "g", 'δ': "d", 'ε': "e", 'ζ': "z", 'η': "i", 'θ': "th", 'ι': "i", 'κ': "k", 'λ': "l",
+	'μ': "m", 'ν': "n", 'ξ': "x", 'ο': "o", 'π': "p", 'ρ': "r", 'σ': "s", 'ς': "s", 'τ': "t", 'υ': "y", 'φ': "f",
+	'χ': "ch", 'ψ': "ps", 'ω': "o",
+}
+
+
+var transliterationsOnce sync.Once
+
+func getTransliterations() map[rune]string {
+	transliterationsOnce.Do(func() {
+		for r, latin := range transliterations {
+			if upper := unicode.ToUpper(r); upper != r {
+				if _, ok := transliterations[upper]; !ok {
+					transliterations[upper] = capitalize(latin)
+				}
+			}
+		}
+	})
+
+	return transliterations
+}
+
+// toASCII transliterates the letters of other scripts, strips diacritics and removes the remaining non ASCII
+// characters (e.g. "Ćevapčići в София" -> "Cevapcici v Sofiya")
+func toASCII(s string) string {
+	if isASCII(s) {
+		return s
+	}
+
+	data := getUnicodeData()
+	table := getTransliterations()
+	var result strings.Builder
+
+	var write func(r rune, decompose bool)
+	write = func(r rune, decompose bool) {
+		switch latin, ok := table[r]; {
+		case r < utf8.RuneSelf:
+			result.WriteRune(r)
+		case ok:
+			result.WriteString(latin)
+		case decompose:
+			for _, part := range data.decompose(string(r), true) {
+				if !unicode.Is(unicode.Mn, part) {
+					write(part, false)
+				}
+			}
+		}
+	}
+
+	for _, r := range normalize(s, TagNFC) {
+		write(r, true)
+	}
+
+	return result.String()
+}
+
+// skeleton returns a skeleton of a string following the TR39 algorithm with the confusables table, two strings
+// are confusable if their skeletons are equal (e.g. "pаypаl" with Cyrillic "а" letters and "paypal" both have the
+// skeleton "paypal")
+func skeleton(s string) string {
+	data := getUnicodeData()
+	var result strings.Builder
+	for _, r := range data.decompose(s, false) {
+		if prototype, ok := data.confusables[r]; ok {
+			result.WriteString(string(prototype))
+			continue
+		}
+
+		if entry := data.decompositions[r]; entry != nil && entry.variant {
+			result.WriteString(string(entry.compatibility))
+			continue
+		}
+
+		result.WriteRune(r)
+	}
+
+	return normalize(result.String(), TagNFD)
+}
@@ -0,0 +1,82 @@
+/*
+	MIT License
+
+	Copyright (c) 2022 Antony Jekov
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in all
+	copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+	SOFTWARE.
+*/
+
+package morph
+
+import (
+	"reflect"
+)
+
+//region Normalization
+
+type normalizationTransformer struct {
+	ParameterlessTransformer
+	form string
+}
+
+func (t *normalizationTransformer) Transform(value *reflect.Value, _ *string) error {
+	return transformString(value, t.form, func(s string) string {
+		return normalize(s, t.form)
+	})
+}
+
+//endregion Normalization
+
+//region CaseFold
+
+type caseFoldTransformer struct {
+	ParameterlessTransformer
+}
+
+func (t *caseFoldTransformer) Transform(value *reflect.Value, _ *string) error {
+	return transformString(value, TagCaseFold, func(s string) string {
+		return normalize(caseFold(normalize(s, TagNFD)), TagNFC)
+	})
+}
+
+//endregion CaseFold
+
+//region ASCII
+
+type asciiTransformer struct {
+	ParameterlessTransformer
+}
+
+func (t *asciiTransformer) Transform(value *reflect.Value, _ *string) error {
+	return transformString(value, TagASCII, toASCII)
+}
+
+//endregion ASCII
+
+//region Confusables
+
+type confusablesTransformer struct {
+	ParameterlessTransformer
+}
+
+func (t *confusablesTransformer) Transform(value *reflect.Value, _ *string) error {
+	return transformString(value, TagConfusables, skeleton)
+}
+
+//endregion Confusables
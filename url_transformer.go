@@ -0,0 +1,324 @@
+/*
+	MIT License
+
+	Copyright (c) 2022 Antony Jekov
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in all
+	copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+	SOFTWARE.
+*/
+
+package morph
+
+import (
+	"net/url"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const upperHex = "0123456789ABCDEF"
+
+var defaultPorts = map[string]string{
+	"ftp":   "21",
+	"http":  "80",
+	"https": "443",
+	"ws":    "80",
+	"wss":   "443",
+}
+
+type urlOptions struct {
+	stripParams   []string
+	stripFragment bool
+}
+
+func (o *urlOptions) strips(name string) bool {
+	for _, pattern := range o.stripParams {
+		if pattern == name || strings.HasSuffix(pattern, "*") && strings.HasPrefix(name, pattern[:len(pattern)-1]) {
+			return true
+		}
+	}
+
+	return false
+}
+
+type urlTransformer struct {
+	ParameterTransformer
+}
+
+func newURLTransformer(mutex *sync.RWMutex) *urlTransformer {
+	return &urlTransformer{NewParamsTransformer(mutex, parseURLOptions)}
+}
+
+func parseURLOptions(params string) (interface{}, error) {
+	options := &urlOptions{}
+	for _, option := range strings.Fields(params) {
+		switch {
+		case option == URLStripFragment:
+			options.stripFragment = true
+		case strings.HasPrefix(option, URLStripParams+string(ParamsSign)):
+			for _, pattern := range strings.Split(option[len(URLStripParams)+1:], "|") {
+				if len(pattern) == 0 {
+					return nil, newErrorf(ErrInvalidParameters, params, TagURL)
+				}
+				options.stripParams = append(options.stripParams, pattern)
+			}
+		default:
+			return nil, newErrorf(ErrInvalidParameters, params, TagURL)
+		}
+	}
+
+	return options, nil
+}
+
+func (t *urlTransformer) Transform(value *reflect.Value, key *string) error {
+	parsed, ok := t.Get(key)
+	if !ok {
+		return newErrorf(ErrMissingParametersFmt, TagURL)
+	}
+
+	options := parsed.(*urlOptions)
+	if value.Type() == urlType {
+		link := value.Addr().Interface().(*url.URL)
+		if *link == (url.URL{}) {
+			return nil
+		}
+
+		canonical, ok := canonicalizeURL(link.String(), options)
+		if !ok {
+			return newErrorf(ErrInvalidValueFmt, link.String(), TagURL)
+		}
+
+		parsed, _ := url.Parse(canonical)
+		value.Set(reflect.ValueOf(*parsed))
+		return nil
+	}
+
+	if value.Kind() != reflect.String {
+		return newErrorf(ErrUnexpectedValue, value.Type().Kind().String(), TagURL)
+	}
+
+	canonical, ok := canonicalizeURL(value.String(), options)
+	if !ok {
+		return newErrorf(ErrInvalidValueFmt, value.String(), TagURL)
+	}
+
+	value.SetString(canonical)
+	return nil
+}
+
+// canonicalizeURL accepts absolute URLs and returns them in a form which is equal for equivalent URLs as described
+// in RFC 3986. A host is required only by the http, https, ftp, ws and wss schemes. Empty values are left empty.
+func canonicalizeURL(link string, options *urlOptions) (string, bool) {
+	link = strings.TrimFunc(link, isBlank)
+	if len(link) == 0 {
+		return "", true
+	}
+
+	parsed, err := url.Parse(link)
+	if err != nil || len(parsed.Scheme) == 0 {
+		return "", false
+	}
+
+	if len(parsed.Opaque) > 0 {
+		return parsed.Scheme + ":" + normalizePercentEncoding(parsed.Opaque), true
+	}
+
+	// only the network schemes require a host, others such as file:///etc/hosts or mailto: may leave it empty
+	host := ""
+	if _, network := defaultPorts[parsed.Scheme]; network || len(parsed.Host) > 0 {
+		var ok bool
+		if host, ok = canonicalizeHost(parsed); !ok {
+			return "", false
+		}
+	}
+
+	var builder strings.Builder
+	builder.WriteString(parsed.Scheme + ":")
+	if len(host) > 0 || parsed.User != nil || strings.HasPrefix(link[len(parsed.Scheme)+1:], "//") {
+		builder.WriteString("//")
+	}
+	if parsed.User != nil {
+		builder.WriteString(parsed.User.String() + "@")
+	}
+	builder.WriteString(host)
+
+	path := removeDotSegments(normalizePercentEncoding(escaped(parsed.RawPath, parsed.EscapedPath)))
+	if len(path) == 0 && len(host) > 0 {
+		path = "/"
+	}
+	builder.WriteString(path)
+
+	if query := canonicalizeQuery(parsed.RawQuery, options); len(query) > 0 {
+		builder.WriteString("?" + query)
+	}
+
+	if fragment := escaped(parsed.RawFragment, parsed.EscapedFragment); len(fragment) > 0 && !options.stripFragment {
+		builder.WriteString("#" + normalizePercentEncoding(fragment))
+	}
+
+	return builder.String(), true
+}
+
+// escaped returns the original encoding, which url.URL drops if it is not valid, so that it can be normalized
+func escaped(raw string, escape func() string) string {
+	if len(raw) > 0 {
+		return raw
+	}
+
+	return escape()
+}
+
+func canonicalizeHost(parsed *url.URL) (string, bool) {
+	host, port := parsed.Hostname(), parsed.Port()
+	if len(host) == 0 {
+		return "", false
+	}
+
+	if strings.Contains(host, ":") {
+		host = "[" + strings.Replace(strings.ToLower(host), "%", "%25", 1) + "]"
+	} else {
+		var ok bool
+		if host, ok = toASCIIDomain(strings.TrimSuffix(host, ".")); !ok {
+			return "", false
+		}
+	}
+
+	if len(port) == 0 || defaultPorts[parsed.Scheme] == port {
+		return host, true
+	}
+
+	return host + ":" + port, true
+}
+
+func canonicalizeQuery(query string, options *urlOptions) string {
+	type parameter struct {
+		name  string
+		value string
+	}
+
+	var parameters []parameter
+	for _, pair := range strings.Split(query, "&") {
+		if len(pair) == 0 {
+			continue
+		}
+
+		name, value := pair, ""
+		if i := strings.IndexByte(pair, '='); i >= 0 {
+			name, value = pair[:i], pair[i:]
+		}
+
+		name = normalizePercentEncoding(name)
+		if decoded, err := url.QueryUnescape(name); err == nil && options.strips(decoded) {
+			continue
+		}
+
+		parameters = append(parameters, parameter{name, normalizePercentEncoding(value)})
+	}
+
+	sort.SliceStable(parameters, func(i, j int) bool {
+		return parameters[i].name < parameters[j].name
+	})
+
+	pairs := make([]string, len(parameters))
+	for i, parameter := range parameters {
+		pairs[i] = parameter.name + parameter.value
+	}
+
+	return strings.Join(pairs, "&")
+}
+
+// normalizePercentEncoding decodes the unreserved characters, uppercases the remaining escapes and escapes the
+// characters which are not allowed in a URL
+func normalizePercentEncoding(s string) string {
+	var builder strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c == '%' && i+2 < len(s) && isHex(s[i+1]) && isHex(s[i+2]) {
+			decoded := unhex(s[i+1])<<4 | unhex(s[i+2])
+			if isUnreserved(decoded) {
+				builder.WriteByte(decoded)
+			} else {
+				builder.WriteString("%" + strings.ToUpper(s[i+1:i+3]))
+			}
+			i += 2
+			continue
+		}
+
+		if isUnreserved(c) || strings.IndexByte("!$&'()*+,;=:@/?", c) >= 0 {
+			builder.WriteByte(c)
+			continue
+		}
+
+		builder.WriteByte('%')
+		builder.WriteByte(upperHex[c>>4])
+		builder.WriteByte(upperHex[c&15])
+	}
+
+	return builder.String()
+}
+
+// removeDotSegments resolves the "." and ".." segments of a path as described in RFC 3986 section 5.2.4
+func removeDotSegments(path string) string {
+	if !strings.Contains(path, ".") {
+		return path
+	}
+
+	segments := strings.Split(path, "/")
+	output := make([]string, 0, len(segments))
+	for i, segment := range segments {
+		last := i == len(segments)-1
+		switch segment {
+		case ".":
+			if last {
+				output = append(output, "")
+			}
+		case "..":
+			if len(output) > 1 {
+				output = output[:len(output)-1]
+			}
+			if last {
+				output = append(output, "")
+			}
+		default:
+			output = append(output, segment)
+		}
+	}
+
+	return strings.Join(output, "/")
+}
+
+func isUnreserved(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-' || c == '.' ||
+		c == '_' || c == '~'
+}
+
+func isHex(c byte) bool {
+	return c >= '0' && c <= '9' || c >= 'a' && c <= 'f' || c >= 'A' && c <= 'F'
+}
+
+func unhex(c byte) byte {
+	switch {
+	case c >= '0' && c <= '9':
+		return c - '0'
+	case c >= 'a' && c <= 'f':
+		return c - 'a' + 10
+	}
+
+	return c - 'A' + 10
+}
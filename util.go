@@ -26,9 +26,68 @@ package morph
 
 import (
 	"fmt"
+	"math/big"
+	"net"
+	"net/url"
 	"reflect"
+	"strings"
+	"sync"
+	"time"
 )
 
+var (
+	timeType         = reflect.TypeOf(time.Time{})
+	durationType     = reflect.TypeOf(time.Duration(0))
+	bigIntType       = reflect.TypeOf(big.Int{})
+	bigFloatType     = reflect.TypeOf(big.Float{})
+	bigRatType       = reflect.TypeOf(big.Rat{})
+	urlType          = reflect.TypeOf(url.URL{})
+	ipType           = reflect.TypeOf(net.IP{})
+	ipNetType        = reflect.TypeOf(net.IPNet{})
+	hardwareAddrType = reflect.TypeOf(net.HardwareAddr{})
+	stringType       = reflect.TypeOf("")
+)
+
+// isLeafType reports whether a struct type is transformed as a single value instead of being morphed field by field.
+func isLeafType(valueType reflect.Type) bool {
+	switch valueType {
+	case timeType, bigIntType, bigFloatType, bigRatType, urlType, ipNetType:
+		return true
+	}
+
+	return isTimeType(valueType)
+}
+
+// isTimeType reports whether a type is time.Time or a type defined from it
+func isTimeType(valueType reflect.Type) bool {
+	return valueType.Kind() == reflect.Struct && valueType.ConvertibleTo(timeType)
+}
+
+// isDurationType reports whether a type is time.Duration or a type registered with RegisterLeafType, which the types
+// defined from time.Duration have to be, as they cannot be told apart from the ones defined from int64
+func isDurationType(valueType reflect.Type, leaves *leafRegistry) bool {
+	return valueType == durationType || valueType.Kind() == reflect.Int64 && leaves.contains(valueType)
+}
+
+// leafRegistry holds the types registered with RegisterLeafType and is shared by the transformers checking them
+type leafRegistry struct {
+	types map[reflect.Type]bool
+	mutex *sync.RWMutex
+}
+
+func (r *leafRegistry) add(valueType reflect.Type) {
+	r.mutex.Lock()
+	r.types[valueType] = true
+	r.mutex.Unlock()
+}
+
+func (r *leafRegistry) contains(valueType reflect.Type) bool {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	return r.types[valueType]
+}
+
 func getActualValue(dataValue *reflect.Value) *reflect.Value {
 	switch dataValue.Kind() {
 	case reflect.Ptr, reflect.Interface:
@@ -59,13 +118,125 @@ func getAssignableValue(value *reflect.Value, kind *reflect.Kind) *reflect.Value
 }
 
 func assignValue(target, value *reflect.Value, targetKind *reflect.Kind) {
-	if *targetKind == reflect.Ptr {
+	if *targetKind == reflect.Ptr && target.CanSet() {
 		(*target).Set(value.Addr())
-	} else if !target.CanAddr() {
-		target.Set(reflect.Indirect(*value))
 	}
 }
 
 func getParamsKey(valueType string, fieldIndex int) string {
 	return fmt.Sprintf("%s.%d", valueType, fieldIndex)
 }
+
+func getTagParamsKey(fieldKey string, tagIndex int) string {
+	return fmt.Sprintf("%s.%d", fieldKey, tagIndex)
+}
+
+func transformString(value *reflect.Value, tag string, transform func(s string) string) error {
+	if value.Kind() != reflect.String {
+		return newErrorf(ErrUnexpectedValue, value.Type().Kind().String(), tag)
+	}
+
+	value.SetString(transform(value.String()))
+	return nil
+}
+
+// transformCanonicalString trims a string and replaces it with its canonical form, leaving empty values intact
+func transformCanonicalString(value *reflect.Value, tag string, canonicalize func(string) (string, bool)) error {
+	if value.Kind() != reflect.String {
+		return newErrorf(ErrUnexpectedValue, value.Type().Kind().String(), tag)
+	}
+
+	trimmed := strings.TrimFunc(value.String(), isBlank)
+	if len(trimmed) == 0 {
+		value.SetString(trimmed)
+		return nil
+	}
+
+	canonical, ok := canonicalize(trimmed)
+	if !ok {
+		return newErrorf(ErrInvalidValueFmt, value.String(), tag)
+	}
+
+	value.SetString(canonical)
+	return nil
+}
+
+// getFieldReplacer returns the tag of a transformer replacing the whole field if there is one before TagDive
+func getFieldReplacer(tag *tagChainCache) *tagChainCache {
+	for currentTag := tag; currentTag != nil && currentTag.tag != TagDive; currentTag = currentTag.next {
+		if replacer, ok := currentTag.transformer.(fieldReplacer); ok && replacer.replacesField() {
+			return currentTag
+		}
+	}
+
+	return nil
+}
+
+// isBytes reports whether a value is a byte slice such as []byte or json.RawMessage
+func isBytes(value *reflect.Value) bool {
+	return value.Kind() == reflect.Slice && value.Type().Elem().Kind() == reflect.Uint8
+}
+
+// transformBytes applies a transformation to the content of a string or a byte slice, keeping the kind of the value
+func transformBytes(value *reflect.Value, tag string, transform func(b []byte) ([]byte, error)) error {
+	var data []byte
+	switch {
+	case value.Kind() == reflect.String:
+		data = []byte(value.String())
+	case isBytes(value):
+		data = value.Bytes()
+	default:
+		return newErrorf(ErrUnexpectedValue, value.Type().Kind().String(), tag)
+	}
+
+	result, err := transform(data)
+	if err != nil {
+		return newErrorf(ErrInvalidValueFmt, string(data), tag)
+	}
+
+	if value.Kind() == reflect.String {
+		value.SetString(string(result))
+	} else if len(result) > 0 || !value.IsNil() {
+		value.SetBytes(result)
+	}
+
+	return nil
+}
+
+// transformValue calls the transformer of a tag, passing byte slices as strings to the built-in transformers which
+// do not handle them, so that all string transformers accept []byte and json.RawMessage values. Registered
+// transformers receive the byte slices as they are.
+func transformValue(tag *tagChainCache, value *reflect.Value) error {
+	if !isBytes(value) || tag.custom {
+		return tag.transformer.Transform(value, tag.paramsKey)
+	}
+
+	if transformer, ok := tag.transformer.(bytesTransformer); ok && transformer.transformsBytes() {
+		return tag.transformer.Transform(value, tag.paramsKey)
+	}
+
+	err := transformBytesAsString(value, func(text *reflect.Value) error {
+		return tag.transformer.Transform(text, tag.paramsKey)
+	})
+	if isErrorOf(err, ErrUnexpectedValue) {
+		return newErrorf(ErrUnexpectedValue, value.Kind().String(), tag.tag)
+	}
+
+	return err
+}
+
+// transformBytesAsString passes the content of a byte slice as a string to a transformation and stores the result
+// back, keeping nil slices nil when the result is empty
+func transformBytesAsString(value *reflect.Value, transform func(text *reflect.Value) error) error {
+	text := reflect.New(stringType).Elem()
+	text.SetString(string(value.Bytes()))
+	if err := transform(&text); err != nil {
+		return err
+	}
+
+	if text.Len() > 0 || !value.IsNil() {
+		value.SetBytes([]byte(text.String()))
+	}
+
+	return nil
+}
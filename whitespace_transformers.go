@@ -0,0 +1,242 @@
+/*
+	MIT License
+
+	Copyright (c) 2022 Antony Jekov
+
+	Permission is hereby granted, free of charge, to any person obtaining a copy
+	of this software and associated documentation files (the "Software"), to deal
+	in the Software without restriction, including without limitation the rights
+	to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+	copies of the Software, and to permit persons to whom the Software is
+	furnished to do so, subject to the following conditions:
+
+	The above copyright notice and this permission notice shall be included in all
+	copies or substantial portions of the Software.
+
+	THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+	IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+	FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+	AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+	LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+	OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+	SOFTWARE.
+*/
+
+package morph
+
+import (
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+// line endings for TagEOL
+const (
+	eolLF   = "lf"
+	eolCRLF = "crlf"
+)
+
+//region helpers
+
+// isZeroWidth reports whether a rune is an invisible character which is not considered a space by unicode.IsSpace
+func isZeroWidth(r rune) bool {
+	switch r {
+	case '\u200b', '\u200c', '\u200d', '\u2060', '\ufeff', '\u180e':
+		return true
+	}
+
+	return false
+}
+
+// isNonBreakingSpace reports whether a rune is a space which prevents line breaks
+func isNonBreakingSpace(r rune) bool {
+	return r == '\u00a0' || r == '\u2007' || r == '\u202f'
+}
+
+// isBlank reports whether a rune is a whitespace or an invisible zero-width character
+func isBlank(r rune) bool {
+	return unicode.IsSpace(r) || isZeroWidth(r)
+}
+
+//endregion helpers
+
+//region LTrim
+
+type leftTrimTransformer struct {
+	StringParameterTransformer
+}
+
+func (t *leftTrimTransformer) Transform(value *reflect.Value, key *string) error {
+	cutset := t.get(key)
+	return transformString(value, TagLTrim, func(s string) string {
+		if len(cutset) == 0 {
+			return strings.TrimLeftFunc(s, isBlank)
+		}
+
+		return strings.TrimLeft(s, cutset)
+	})
+}
+
+//endregion LTrim
+
+//region RTrim
+
+type rightTrimTransformer struct {
+	StringParameterTransformer
+}
+
+func (t *rightTrimTransformer) Transform(value *reflect.Value, key *string) error {
+	cutset := t.get(key)
+	return transformString(value, TagRTrim, func(s string) string {
+		if len(cutset) == 0 {
+			return strings.TrimRightFunc(s, isBlank)
+		}
+
+		return strings.TrimRight(s, cutset)
+	})
+}
+
+//endregion RTrim
+
+//region TrimPrefix
+
+type trimPrefixTransformer struct {
+	StringParameterTransformer
+}
+
+func (t *trimPrefixTransformer) Cache(params, key *string) error {
+	if len(*params) == 0 {
+		return newErrorf(ErrMissingParametersFmt, TagTrimPrefix)
+	}
+
+	return t.StringParameterTransformer.Cache(params, key)
+}
+
+func (t *trimPrefixTransformer) Transform(value *reflect.Value, key *string) error {
+	prefix := t.get(key)
+	return transformString(value, TagTrimPrefix, func(s string) string {
+		return strings.TrimPrefix(s, prefix)
+	})
+}
+
+//endregion TrimPrefix
+
+//region TrimSuffix
+
+type trimSuffixTransformer struct {
+	StringParameterTransformer
+}
+
+func (t *trimSuffixTransformer) Cache(params, key *string) error {
+	if len(*params) == 0 {
+		return newErrorf(ErrMissingParametersFmt, TagTrimSuffix)
+	}
+
+	return t.StringParameterTransformer.Cache(params, key)
+}
+
+func (t *trimSuffixTransformer) Transform(value *reflect.Value, key *string) error {
+	suffix := t.get(key)
+	return transformString(value, TagTrimSuffix, func(s string) string {
+		return strings.TrimSuffix(s, suffix)
+	})
+}
+
+//endregion TrimSuffix
+
+//region Squish
+
+type squishTransformer struct {
+	ParameterlessTransformer
+}
+
+func (t *squishTransformer) Transform(value *reflect.Value, _ *string) error {
+	return transformString(value, TagSquish, func(s string) string {
+		return strings.Join(strings.FieldsFunc(s, isBlank), " ")
+	})
+}
+
+//endregion Squish
+
+//region NoSpace
+
+type noSpaceTransformer struct {
+	ParameterlessTransformer
+}
+
+func (t *noSpaceTransformer) Transform(value *reflect.Value, _ *string) error {
+	return transformString(value, TagNoSpace, func(s string) string {
+		return strings.Join(strings.FieldsFunc(s, isBlank), "")
+	})
+}
+
+//endregion NoSpace
+
+//region NoZeroWidth
+
+type noZeroWidthTransformer struct {
+	ParameterlessTransformer
+}
+
+func (t *noZeroWidthTransformer) Transform(value *reflect.Value, _ *string) error {
+	return transformString(value, TagNoZeroWidth, func(s string) string {
+		return strings.Map(func(r rune) rune {
+			if isZeroWidth(r) {
+				return -1
+			}
+
+			return r
+		}, s)
+	})
+}
+
+//endregion NoZeroWidth
+
+//region NBSP
+
+type nonBreakingSpaceTransformer struct {
+	ParameterlessTransformer
+}
+
+func (t *nonBreakingSpaceTransformer) Transform(value *reflect.Value, _ *string) error {
+	return transformString(value, TagNBSP, func(s string) string {
+		return strings.Map(func(r rune) rune {
+			if isNonBreakingSpace(r) {
+				return ' '
+			}
+
+			return r
+		}, s)
+	})
+}
+
+//endregion NBSP
+
+//region EOL
+
+type endOfLineTransformer struct {
+	StringParameterTransformer
+}
+
+func (t *endOfLineTransformer) Cache(params, key *string) error {
+	if *params != "" && *params != eolLF && *params != eolCRLF {
+		return newErrorf(ErrInvalidParameters, *params, TagEOL)
+	}
+
+	return t.StringParameterTransformer.Cache(params, key)
+}
+
+func (t *endOfLineTransformer) Transform(value *reflect.Value, key *string) error {
+	eol := t.get(key)
+	return transformString(value, TagEOL, func(s string) string {
+		s = strings.ReplaceAll(s, "\r\n", "\n")
+		s = strings.ReplaceAll(s, "\r", "\n")
+		if eol == eolCRLF {
+			s = strings.ReplaceAll(s, "\n", "\r\n")
+		}
+
+		return s
+	})
+}
+
+//endregion EOL